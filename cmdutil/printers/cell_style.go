@@ -0,0 +1,335 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// CellStyleRule styles the cells of a table column whose value matches a condition, like the
+// cells of a STATUS column that are "Failed". Rules are applied by [TablePrinter] on top of its
+// CellStyle, and before its CellStyleFunc.
+type CellStyleRule struct {
+	// Column is the header of the column that the rule applies to, compared case-insensitively.
+	// Rules from the "style" header tag option apply to the column of their field and leave it
+	// empty.
+	Column string
+	// Match reports whether the rule applies to the value of a cell, before it is truncated.
+	Match func(value string) bool
+	// Style is applied to the cells that match. When several rules match a cell, the properties
+	// set by later rules take precedence.
+	Style lipgloss.Style
+}
+
+// cellStyles holds the lipgloss.Style of every name, see RegisterCellStyle.
+var cellStyles = struct {
+	sync.RWMutex
+	styles map[string]lipgloss.Style
+}{styles: map[string]lipgloss.Style{
+	"red":       lipgloss.NewStyle().Foreground(lipgloss.Color("1")),
+	"green":     lipgloss.NewStyle().Foreground(lipgloss.Color("2")),
+	"yellow":    lipgloss.NewStyle().Foreground(lipgloss.Color("3")),
+	"blue":      lipgloss.NewStyle().Foreground(lipgloss.Color("4")),
+	"magenta":   lipgloss.NewStyle().Foreground(lipgloss.Color("5")),
+	"cyan":      lipgloss.NewStyle().Foreground(lipgloss.Color("6")),
+	"gray":      lipgloss.NewStyle().Foreground(gray),
+	"bold":      lipgloss.NewStyle().Bold(true),
+	"faint":     lipgloss.NewStyle().Faint(true),
+	"italic":    lipgloss.NewStyle().Italic(true),
+	"underline": lipgloss.NewStyle().Underline(true),
+}}
+
+// RegisterCellStyle registers a lipgloss.Style under a name for CellStyleRules, replacing any style
+// already registered under that name, including the built-in styles: the colors red, green,
+// yellow, blue, magenta, cyan and gray, and the attributes bold, faint, italic and underline.
+//
+// It is safe for concurrent use.
+func RegisterCellStyle(name string, style lipgloss.Style) {
+	cellStyles.Lock()
+	cellStyles.styles[name] = style
+	cellStyles.Unlock()
+
+	// compiled plans hold the styles of the "style" header tag options that were registered when
+	// they were compiled
+	tablePlanCache.Lock()
+	defer tablePlanCache.Unlock()
+	clear(tablePlanCache.plans)
+}
+
+// ParseCellStyleRule parses a rule of the form "COLUMN<CONDITION>:STYLE", like "STATUS=Failed:red"
+// or "CPU>=90:red+bold".
+//
+// The condition is one of:
+//
+//   - =VALUE or !=VALUE: the cell is, or is not, exactly VALUE.
+//   - ~REGEXP: the cell matches a regular expression.
+//   - >N, >=N, <N or <=N: the cell starts with a number, like "42" or "85%", compared to N.
+//
+// The style is the name of a style registered with RegisterCellStyle, an ANSI color number like
+// "208", or a hex color like "#ff8800", and several styles are combined with "+".
+func ParseCellStyleRule(spec string) (CellStyleRule, error) {
+	condition, style, ok := cutLast(spec, ":")
+	if !ok {
+		return CellStyleRule{}, fmt.Errorf("invalid cell style rule %q: missing style", spec)
+	}
+	i := strings.IndexAny(condition, "=!<>~")
+	if i < 0 {
+		return CellStyleRule{}, fmt.Errorf("invalid cell style rule %q: missing condition", spec)
+	}
+	column := strings.TrimSpace(condition[:i])
+	if column == "" {
+		return CellStyleRule{}, fmt.Errorf("invalid cell style rule %q: missing column", spec)
+	}
+	rule, err := newCellStyleRule(column, condition[i:], style)
+	if err != nil {
+		return CellStyleRule{}, fmt.Errorf("invalid cell style rule %q: %w", spec, err)
+	}
+	return rule, nil
+}
+
+// LoadCellStyleRules reads CellStyleRules from a YAML (or JSON) config file. The file is a list of
+// rules, each either a string parsed by ParseCellStyleRule or an object with the column, the
+// condition (where a plain value means "=value") and the style of the rule:
+//
+//	# cell-styles.yaml
+//	- STATUS=Failed:red
+//	- column: READY
+//	  match: "false"
+//	  style: yellow
+//	- column: CPU
+//	  match: ">=90"
+//	  style: red+bold
+func LoadCellStyleRules(r io.Reader) ([]CellStyleRule, error) {
+	var configs []cellStyleRuleConfig
+	if err := yaml.NewDecoder(r).Decode(&configs); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid cell style rules: %w", err)
+	}
+	rules := make([]CellStyleRule, 0, len(configs))
+	for _, config := range configs {
+		rules = append(rules, config.rule)
+	}
+	return rules, nil
+}
+
+// cellStyleRuleConfig is a CellStyleRule of a config file, see LoadCellStyleRules.
+type cellStyleRuleConfig struct {
+	rule CellStyleRule
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (c *cellStyleRuleConfig) UnmarshalYAML(node *yaml.Node) error {
+	var err error
+	if node.Kind == yaml.ScalarNode {
+		c.rule, err = ParseCellStyleRule(node.Value)
+		return err
+	}
+	var config struct {
+		Column string `yaml:"column"`
+		Match  string `yaml:"match"`
+		Style  string `yaml:"style"`
+	}
+	if err = node.Decode(&config); err != nil {
+		return err
+	}
+	if config.Column == "" {
+		return fmt.Errorf("line %d: invalid cell style rule: missing column", node.Line)
+	}
+	if c.rule, err = newCellStyleRule(config.Column, config.Match, config.Style); err != nil {
+		return fmt.Errorf("line %d: invalid cell style rule: %w", node.Line, err)
+	}
+	return nil
+}
+
+// parseTagCellStyleRules parses the rules of a "style" header tag option, which are separated by
+// semicolons and have no column, like "Failed:red;Running:green". Invalid rules are ignored.
+func parseTagCellStyleRules(spec string) []CellStyleRule {
+	var rules []CellStyleRule
+	for _, s := range strings.Split(spec, ";") {
+		condition, style, ok := cutLast(s, ":")
+		if !ok {
+			continue
+		}
+		if rule, err := newCellStyleRule("", condition, style); err == nil {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// newCellStyleRule returns the rule of a column, a condition and a style. A condition without an
+// operator matches cells that are exactly the condition.
+func newCellStyleRule(column, condition, style string) (CellStyleRule, error) {
+	match, err := parseCellStyleCondition(condition)
+	if err != nil {
+		return CellStyleRule{}, err
+	}
+	s, err := parseCellStyle(style)
+	if err != nil {
+		return CellStyleRule{}, err
+	}
+	return CellStyleRule{Column: column, Match: match, Style: s}, nil
+}
+
+// leadingNumber matches the number at the start of a cell, like "85" in "85%".
+var leadingNumber = regexp.MustCompile(`^\s*[-+]?(\d+\.?\d*|\.\d+)`)
+
+// parseLeadingNumber returns the number at the start of a cell, if it starts with one.
+func parseLeadingNumber(value string) (float64, bool) {
+	n, err := strconv.ParseFloat(strings.TrimSpace(leadingNumber.FindString(value)), 64)
+	return n, err == nil
+}
+
+func parseCellStyleCondition(condition string) (func(value string) bool, error) {
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		operand, ok := strings.CutPrefix(condition, op)
+		if !ok {
+			continue
+		}
+		threshold, err := strconv.ParseFloat(strings.TrimSpace(operand), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid threshold %q", operand)
+		}
+		return func(value string) bool {
+			n, ok := parseLeadingNumber(value)
+			if !ok {
+				return false
+			}
+			switch op {
+			case ">=":
+				return n >= threshold
+			case "<=":
+				return n <= threshold
+			case ">":
+				return n > threshold
+			default:
+				return n < threshold
+			}
+		}, nil
+	}
+	if operand, ok := strings.CutPrefix(condition, "~"); ok {
+		re, err := regexp.Compile(operand)
+		if err != nil {
+			return nil, err
+		}
+		return re.MatchString, nil
+	}
+	if operand, ok := strings.CutPrefix(condition, "!="); ok {
+		return func(value string) bool { return value != operand }, nil
+	}
+	operand := strings.TrimPrefix(condition, "=")
+	return func(value string) bool { return value == operand }, nil
+}
+
+// parseCellStyle returns the style of "+" separated style names and colors, see ParseCellStyleRule.
+func parseCellStyle(spec string) (lipgloss.Style, error) {
+	style := lipgloss.NewStyle()
+	for _, name := range strings.Split(spec, "+") {
+		name = strings.TrimSpace(name)
+		cellStyles.RLock()
+		s, ok := cellStyles.styles[name]
+		cellStyles.RUnlock()
+		if !ok {
+			if !strings.HasPrefix(name, "#") && !isDigits(name) {
+				return lipgloss.Style{}, fmt.Errorf("unknown cell style %q", name)
+			}
+			s = lipgloss.NewStyle().Foreground(lipgloss.Color(name))
+		}
+		style = s.Inherit(style)
+	}
+	return style, nil
+}
+
+// cellRuleStyles returns the combined style of the rules that match every cell of rows, by row and
+// column, or nil if no rule applies to any column. Cells that no rule matches have a nil style.
+// The rules of each column are its layout's rules and the rules of its header.
+func cellRuleStyles(
+	headers []string,
+	layouts []ColumnLayout,
+	rules []CellStyleRule,
+	rows [][]string,
+) [][]*lipgloss.Style {
+	columnRules := make([][]CellStyleRule, len(headers))
+	found := false
+	for col, header := range headers {
+		if col < len(layouts) {
+			columnRules[col] = append(columnRules[col], layouts[col].Styles...)
+		}
+		for _, rule := range rules {
+			if strings.EqualFold(rule.Column, header) {
+				columnRules[col] = append(columnRules[col], rule)
+			}
+		}
+		found = found || len(columnRules[col]) > 0
+	}
+	if !found {
+		return nil
+	}
+
+	styles := make([][]*lipgloss.Style, len(rows))
+	for r, row := range rows {
+		styles[r] = make([]*lipgloss.Style, len(row))
+		for col, cell := range row {
+			if col >= len(columnRules) {
+				break
+			}
+			for _, rule := range columnRules[col] {
+				if rule.Match == nil || !rule.Match(cell) {
+					continue
+				}
+				style := rule.Style
+				if styles[r][col] != nil {
+					style = style.Inherit(*styles[r][col])
+				}
+				styles[r][col] = &style
+			}
+		}
+	}
+	return styles
+}
+
+// applyCellStyle returns base with the properties set by style. Unlike lipgloss.Style.Inherit, the
+// padding of base is kept.
+func applyCellStyle(base, style lipgloss.Style) lipgloss.Style {
+	return style.Inherit(base).Padding(base.GetPadding())
+}
+
+// cutLast slices s around the last instance of sep, like strings.Cut.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+func isDigits(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cell styles", Label("unit"), func() {
+	var (
+		red    = lipgloss.Color("1")
+		yellow = lipgloss.Color("3")
+	)
+
+	DescribeTable("ParseCellStyleRule conditions",
+		func(spec, value string, expected bool) {
+			rule, err := printers.ParseCellStyleRule(spec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rule.Match(value)).To(Equal(expected))
+		},
+		Entry("an equal value", "STATUS=Failed:red", "Failed", true),
+		Entry("a different value", "STATUS=Failed:red", "Running", false),
+		Entry("a not equal value", "STATUS!=Running:red", "Failed", true),
+		Entry("a matching regular expression", "MESSAGE~(?i)error:red", "an Error occurred", true),
+		Entry("a number above a threshold", "CPU>=90:red", "95", true),
+		Entry("a percentage below a threshold", "CPU>=90:red", "85%", false),
+		Entry("a size above a threshold", "SIZE>1:red", "1.5 GiB", true),
+		Entry("a number below a threshold", "RESTARTS<1:red", "0", true),
+		Entry("a value that is not a number", "CPU>=90:red", "unknown", false),
+		Entry("a value with a colon", "TIME=12:00:red", "12:00", true),
+	)
+
+	DescribeTable("invalid rules",
+		func(spec, expected string) {
+			_, err := printers.ParseCellStyleRule(spec)
+			Expect(err).To(MatchError(ContainSubstring(expected)))
+		},
+		Entry("without a style", "STATUS=Failed", "missing style"),
+		Entry("without a column", "=Failed:red", "missing column"),
+		Entry("with a blank column", " =Failed:red", "missing column"),
+		Entry("without a condition", "STATUS:red", `invalid cell style rule "STATUS:red": missing condition`),
+		Entry("with an unknown style", "STATUS=Failed:scarlet", `unknown cell style "scarlet"`),
+		Entry("with an invalid threshold", "CPU>high:red", `invalid threshold "high"`),
+		Entry("with an invalid regular expression", "STATUS~(:red", "missing closing )"),
+	)
+
+	It("should parse the column of a rule", func() {
+		rule := lo.Must(printers.ParseCellStyleRule("CPU % >= 90:red"))
+		Expect(rule.Column).To(Equal("CPU %"))
+		Expect(rule.Match("95")).To(BeTrue())
+	})
+
+	It("should combine styles and colors", func() {
+		rule := lo.Must(printers.ParseCellStyleRule("STATUS=Failed:bold+#ff0000"))
+		Expect(rule.Style.GetBold()).To(BeTrue())
+		Expect(rule.Style.GetForeground()).To(Equal(lipgloss.Color("#ff0000")))
+	})
+
+	It("should resolve registered styles", func() {
+		printers.RegisterCellStyle("danger", lipgloss.NewStyle().Background(red))
+		rule := lo.Must(printers.ParseCellStyleRule("STATUS=Failed:danger"))
+		Expect(rule.Style.GetBackground()).To(Equal(red))
+	})
+
+	Describe("LoadCellStyleRules", func() {
+		It("should load rules from strings and objects", func() {
+			rules, err := printers.LoadCellStyleRules(strings.NewReader(
+				"- STATUS=Failed:red\n- column: READY\n  match: \"false\"\n  style: yellow\n",
+			))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rules).To(HaveLen(2))
+			Expect(rules[1].Column).To(Equal("READY"))
+			Expect(rules[1].Match("false")).To(BeTrue())
+			Expect(rules[1].Style.GetForeground()).To(Equal(yellow))
+		})
+
+		It("should load no rules from an empty file", func() {
+			Expect(printers.LoadCellStyleRules(strings.NewReader(""))).To(BeEmpty())
+		})
+
+		It("should return an error for an invalid rule", func() {
+			_, err := printers.LoadCellStyleRules(strings.NewReader("- match: Failed\n  style: red\n"))
+			Expect(err).To(MatchError(ContainSubstring("line 1: invalid cell style rule: missing column")))
+		})
+	})
+
+	Describe("TablePrinter", func() {
+		type pod struct {
+			Name     string
+			Status   string `header:"STATUS,style=Failed:red;Pending:yellow"`
+			Restarts int    `header:"RESTARTS"`
+		}
+		pods := []pod{
+			{Name: "a", Status: "Running"},
+			{Name: "b", Status: "Failed", Restarts: 12},
+			{Name: "c", Status: "Pending", Restarts: 3},
+		}
+
+		var (
+			printer     *printers.TablePrinter
+			foregrounds map[[2]int]lipgloss.TerminalColor
+		)
+
+		BeforeEach(func() {
+			p, ok := printers.NewTablePrinter(printers.PrintOptions{}).(*printers.TablePrinter)
+			Expect(ok).To(BeTrue())
+			printer = p
+			foregrounds = map[[2]int]lipgloss.TerminalColor{}
+			printer.CellStyleFunc = func(style lipgloss.Style, row, col int, _ string) lipgloss.Style {
+				foregrounds[[2]int{row, col}] = style.GetForeground()
+				return style
+			}
+		})
+
+		It("should style cells with the rules of their header tag and CellStyleRules", func() {
+			printer.CellStyleRules = []printers.CellStyleRule{
+				lo.Must(printers.ParseCellStyleRule("restarts>=10:red")),
+			}
+			Expect(printer.PrintObj(pods, &bytes.Buffer{})).To(Succeed())
+			Expect(foregrounds[[2]int{1, 1}]).To(Equal(printers.DefaultTableCellStyle.GetForeground()))
+			Expect(foregrounds[[2]int{2, 1}]).To(Equal(red))
+			Expect(foregrounds[[2]int{2, 2}]).To(Equal(red))
+			Expect(foregrounds[[2]int{3, 1}]).To(Equal(yellow))
+			Expect(foregrounds[[2]int{3, 2}]).To(Equal(printers.DefaultTableCellStyle.GetForeground()))
+		})
+
+		It("should match cells before they are truncated", func() {
+			printer.MaxColumnWidth = 3
+			Expect(printer.PrintObj(pods, &bytes.Buffer{})).To(Succeed())
+			Expect(foregrounds[[2]int{2, 1}]).To(Equal(red))
+		})
+
+		It("should keep the padding and width of the cell style", func() {
+			printer.CellStyleFunc = nil
+			var plain, styled bytes.Buffer
+			Expect(printer.PrintObj(pods, &plain)).To(Succeed())
+			printer.CellStyleRules = []printers.CellStyleRule{lo.Must(printers.ParseCellStyleRule("NAME=b:bold"))}
+			Expect(printer.PrintObj(pods, &styled)).To(Succeed())
+			Expect(styled.String()).To(Equal(plain.String()))
+		})
+
+		It("should style the rows of a stream", func() {
+			stream := printer.NewStream(&bytes.Buffer{})
+			for _, p := range pods {
+				Expect(stream.Write(p)).To(Succeed())
+			}
+			Expect(stream.Close()).To(Succeed())
+			Expect(foregrounds[[2]int{2, 1}]).To(Equal(red))
+			Expect(foregrounds[[2]int{3, 1}]).To(Equal(yellow))
+		})
+	})
+
+	Describe("TableCSVPrinterFlags", func() {
+		It("should add the cell style flags", func() {
+			flags := &printers.TableCSVPrinterFlags{CellStyles: &[]string{}, CellStylesFile: lo.ToPtr("")}
+			cmd := &cobra.Command{}
+			flags.AddFlags(cmd)
+			Expect(cmd.ParseFlags([]string{"--cell-style", "STATUS=Failed:red", "--cell-style", "CPU>90:red"})).
+				To(Succeed())
+			Expect(*flags.CellStyles).To(Equal([]string{"STATUS=Failed:red", "CPU>90:red"}))
+			Expect(cmd.Flag("cell-styles-file")).NotTo(BeNil())
+		})
+
+		It("should return a TablePrinter with the rules of the file and the flags", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "cell-styles.yaml")
+			Expect(os.WriteFile(path, []byte("- STATUS=Failed:red\n"), 0o600)).To(Succeed())
+			flags := &printers.TableCSVPrinterFlags{
+				CellStyles:     &[]string{"READY=false:yellow"},
+				CellStylesFile: &path,
+			}
+			printer, err := flags.ToPrinter("wide")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(printer).To(HaveField("CellStyleRules", HaveLen(2)))
+			Expect(printer).To(HaveField("CellStyleRules", HaveEach(HaveField("Column", BeElementOf("STATUS", "READY")))))
+		})
+
+		It("should return an error for an invalid rule", func() {
+			flags := &printers.TableCSVPrinterFlags{CellStyles: &[]string{"STATUS"}}
+			printer, err := flags.ToPrinter("table")
+			Expect(err).To(MatchError(ContainSubstring("invalid cell style rule")))
+			Expect(printer).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/samber/lo"
+)
+
+// Column is a typed column definition of a Columns table.
+type Column[T any] struct {
+	// Header is the header label of the column.
+	Header string
+	// Get returns the value of the column for a row.
+	Get func(row T) any
+	// Format optionally formats the values returned by Get, like ValueFormat("bytes"). By default,
+	// values are printed like the fields resolved by GenerateTableData, and nil values are printed
+	// as empty cells.
+	Format func(value any) string
+	// Align is the alignment of the column, for printers that support it.
+	Align Alignment
+	// Wide only includes the column when printing wide output (see [PrintOptions.Wide]).
+	Wide bool
+}
+
+// Columns declares the columns of a table of values of type T in code, as an alternative to
+// struct field tags. This supports columns that tags can't express, like computed values, and
+// types that can't be tagged, like types from other packages.
+//
+// Columns are declared with Add, and configured by the methods that follow it:
+//
+//	columns := printers.NewColumns[Pod]().
+//	    Add("NAME", func(p Pod) any { return p.Namespace + "/" + p.Name }).
+//	    Add("RESTARTS", func(p Pod) any { return p.Restarts }).Align(printers.AlignRight).
+//	    Add("NODE", func(p Pod) any { return p.Node }).Wide()
+//
+// Apply configures a printer to resolve its tables from the columns, or TableReflectorFunc can be
+// used directly.
+type Columns[T any] struct {
+	columns []Column[T]
+}
+
+// NewColumns returns Columns with the given column definitions.
+func NewColumns[T any](columns ...Column[T]) *Columns[T] {
+	return &Columns[T]{columns: columns}
+}
+
+// Add appends a column with the given header and getter.
+func (c *Columns[T]) Add(header string, get func(row T) any) *Columns[T] {
+	c.columns = append(c.columns, Column[T]{Header: header, Get: get})
+	return c
+}
+
+// Format sets the formatter of the last added column (see [Column.Format]).
+func (c *Columns[T]) Format(format func(value any) string) *Columns[T] {
+	return c.last(func(column *Column[T]) { column.Format = format })
+}
+
+// Align sets the alignment of the last added column.
+func (c *Columns[T]) Align(align Alignment) *Columns[T] {
+	return c.last(func(column *Column[T]) { column.Align = align })
+}
+
+// Wide only includes the last added column when printing wide output.
+func (c *Columns[T]) Wide() *Columns[T] {
+	return c.last(func(column *Column[T]) { column.Wide = true })
+}
+
+// last configures the last added column, if any.
+func (c *Columns[T]) last(fn func(column *Column[T])) *Columns[T] {
+	if len(c.columns) > 0 {
+		fn(&c.columns[len(c.columns)-1])
+	}
+	return c
+}
+
+// selected returns the columns included by the given PrintOptions.
+func (c *Columns[T]) selected(options PrintOptions) []Column[T] {
+	return lo.Filter(c.columns, func(column Column[T], _ int) bool { return options.Wide || !column.Wide })
+}
+
+// TableReflectorFunc returns a TableReflectorFunc that resolves a row from every value of type T
+// (or *T) in the data, which may be a single value or a slice, array or stream of them (see
+// PrintStream). Wide columns are only included if [PrintOptions.Wide] is set.
+func (c *Columns[T]) TableReflectorFunc(options PrintOptions) TableReflectorFunc {
+	columns := c.selected(options)
+	headers := lo.Map(columns, func(column Column[T], _ int) string { return column.Header })
+	return func(data any) (_ []string, rows [][]string, err error) {
+		rows = [][]string{}
+		if data == nil {
+			return headers, rows, nil
+		}
+		row := func(obj any) error {
+			value, ok, valueErr := columnsValue[T](obj)
+			if ok {
+				rows = append(rows, lo.Map(columns, func(column Column[T], _ int) string {
+					return column.cell(value)
+				}))
+			}
+			return valueErr
+		}
+		if _, ok := data.(T); ok {
+			err = row(data)
+		} else {
+			err = rangeElements(data, row)
+		}
+		return headers, rows, err
+	}
+}
+
+// Alignments returns the alignment of every column included by the given PrintOptions, like
+// [MarkdownPrinter.Alignments].
+func (c *Columns[T]) Alignments(options PrintOptions) []Alignment {
+	return lo.Map(c.selected(options), func(column Column[T], _ int) Alignment { return column.Align })
+}
+
+// Apply configures a printer to resolve its tables from the columns, using the PrintOptions of the
+// printer. It supports TablePrinter, CSVPrinter, MarkdownPrinter and PlainPrinter, including when
+// wrapped by a WatchPrinter, and returns false for any other printer. TablePrinter cells are aligned
+// by the columns' alignments.
+func (c *Columns[T]) Apply(printer ObjectPrinter) bool {
+	switch p := printer.(type) {
+	case *TablePrinter:
+		p.TableReflectorFunc = c.TableReflectorFunc(p.PrintOptions)
+		p.ColumnLayoutFunc = nil
+		alignments := c.Alignments(p.PrintOptions)
+		cellStyleFunc := p.CellStyleFunc
+		p.CellStyleFunc = func(style lipgloss.Style, row, col int, value string) lipgloss.Style {
+			if col < len(alignments) {
+				if position, ok := alignments[col].position(); ok {
+					style = style.Align(position)
+				}
+			}
+			if cellStyleFunc != nil {
+				return cellStyleFunc(style, row, col, value)
+			}
+			return style
+		}
+	case *CSVPrinter:
+		p.TableReflectorFunc = c.TableReflectorFunc(p.PrintOptions)
+	case *MarkdownPrinter:
+		p.TableReflectorFunc = c.TableReflectorFunc(p.PrintOptions)
+		p.Alignments = c.Alignments(p.PrintOptions)
+	case *PlainPrinter:
+		p.TableReflectorFunc = c.TableReflectorFunc(p.PrintOptions)
+	case *WatchPrinter:
+		return c.Apply(p.Printer)
+	default:
+		return false
+	}
+	return true
+}
+
+// cell resolves the cell of the column for a row.
+func (c Column[T]) cell(row T) string {
+	value := c.Get(row)
+	if c.Format != nil {
+		return c.Format(value)
+	}
+	if v, ok := indirectValue(reflect.ValueOf(value)); ok && v.IsValid() {
+		return resolveCellValue(v)
+	}
+	return ""
+}
+
+// columnsValue returns obj as a T, dereferencing a *T. It returns false without an error for a nil
+// *T, and an error if obj is neither a T nor a *T.
+func columnsValue[T any](obj any) (T, bool, error) {
+	switch value := obj.(type) {
+	case T:
+		return value, true, nil
+	case *T:
+		if value == nil {
+			return *new(T), false, nil
+		}
+		return *value, true, nil
+	default:
+		return *new(T), false, fmt.Errorf(
+			"cannot resolve a table row of %s from %T",
+			reflect.TypeOf((*T)(nil)).Elem(),
+			obj,
+		)
+	}
+}
+
+// position returns the lipgloss position of the alignment, or false for AlignDefault.
+func (a Alignment) position() (lipgloss.Position, bool) {
+	switch a {
+	case AlignLeft:
+		return lipgloss.Left, true
+	case AlignCenter:
+		return lipgloss.Center, true
+	case AlignRight:
+		return lipgloss.Right, true
+	case AlignDefault:
+		fallthrough
+	default:
+		return 0, false
+	}
+}
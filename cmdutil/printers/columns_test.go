@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Columns", Label("unit"), func() {
+	type pod struct {
+		Namespace string
+		Name      string
+		Restarts  int
+		Node      *string
+		Started   time.Duration
+	}
+
+	var (
+		columns *printers.Columns[pod]
+		pods    []pod
+		buffer  *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		node := "node-a"
+		columns = printers.NewColumns[pod]().
+			Add("NAME", func(p pod) any { return p.Namespace + "/" + p.Name }).
+			Add("RESTARTS", func(p pod) any { return p.Restarts }).Align(printers.AlignRight).
+			Add("UPTIME", func(p pod) any { return p.Started }).
+			Format(func(v any) string {
+				d, _ := v.(time.Duration)
+				return fmt.Sprintf("%.0fm", d.Minutes())
+			}).
+			Add("NODE", func(p pod) any { return p.Node }).Wide()
+		pods = []pod{
+			{Namespace: "default", Name: "web", Restarts: 3, Node: &node, Started: time.Hour},
+			{Namespace: "default", Name: "worker", Started: time.Minute},
+		}
+		buffer = new(bytes.Buffer)
+	})
+
+	Describe("TableReflectorFunc", func() {
+		It("should resolve a row for every value", func() {
+			headers, rows, err := columns.TableReflectorFunc(printers.PrintOptions{})(pods)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(headers).To(Equal([]string{"NAME", "RESTARTS", "UPTIME"}))
+			Expect(rows).To(Equal([][]string{{"default/web", "3", "60m"}, {"default/worker", "0", "1m"}}))
+		})
+
+		It("should include wide columns when Wide is set", func() {
+			headers, rows, err := columns.TableReflectorFunc(printers.PrintOptions{Wide: true})(pods)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(headers).To(Equal([]string{"NAME", "RESTARTS", "UPTIME", "NODE"}))
+			Expect(rows).To(Equal([][]string{
+				{"default/web", "3", "60m", "node-a"},
+				{"default/worker", "0", "1m", ""},
+			}))
+		})
+
+		It("should resolve single values and pointers", func() {
+			reflector := columns.TableReflectorFunc(printers.PrintOptions{})
+
+			_, rows, err := reflector(pods[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rows).To(Equal([][]string{{"default/web", "3", "60m"}}))
+
+			_, rows, err = reflector([]*pod{&pods[1], nil})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rows).To(Equal([][]string{{"default/worker", "0", "1m"}}))
+
+			headers, rows, err := reflector(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(headers).To(HaveLen(3))
+			Expect(rows).To(BeEmpty())
+		})
+
+		It("should return an error for values of another type", func() {
+			_, _, err := columns.TableReflectorFunc(printers.PrintOptions{})([]string{"a"})
+			Expect(err).To(MatchError(ContainSubstring("cannot resolve a table row of")))
+		})
+	})
+
+	Describe("Alignments", func() {
+		It("should return the alignment of every selected column", func() {
+			Expect(columns.Alignments(printers.PrintOptions{Wide: true})).To(Equal([]printers.Alignment{
+				printers.AlignDefault,
+				printers.AlignRight,
+				printers.AlignDefault,
+				printers.AlignDefault,
+			}))
+		})
+	})
+
+	Describe("Apply", func() {
+		It("should configure a CSVPrinter", func() {
+			printer := printers.NewCSVPrinter(printers.PrintOptions{Wide: true})
+			Expect(columns.Apply(printer)).To(BeTrue())
+			Expect(printer.PrintObj(pods, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("" +
+				"NAME,RESTARTS,UPTIME,NODE\n" +
+				"default/web,3,60m,node-a\n" +
+				"default/worker,0,1m,\n",
+			))
+		})
+
+		It("should configure a MarkdownPrinter with the column alignments", func() {
+			printer := printers.NewMarkdownPrinter(printers.PrintOptions{})
+			Expect(columns.Apply(printer)).To(BeTrue())
+			Expect(printer.PrintObj(pods, buffer)).To(Succeed())
+			Expect(buffer.String()).To(ContainSubstring("| -------------- | -------: | ------ |"))
+		})
+
+		It("should configure a TablePrinter with the column alignments", func() {
+			printer := printers.NewTablePrinter(printers.PrintOptions{})
+			Expect(columns.Apply(printer)).To(BeTrue())
+			Expect(printer.PrintObj(pods, buffer)).To(Succeed())
+			lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+			Expect(lines[1]).To(MatchRegexp(`^│\s*NAME\s*│\s*RESTARTS\s*│\s*UPTIME\s*│$`))
+			Expect(lines[3]).To(MatchRegexp(`^│\s*default/web\s*│\s+3 │\s*60m\s*│$`))
+		})
+
+		It("should configure the printer of a WatchPrinter", func() {
+			printer := printers.NewPlainPrinter(printers.PrintOptions{})
+			Expect(columns.Apply(printers.NewWatchPrinter(printer))).To(BeTrue())
+			Expect(printer.PrintObj(pods[0], buffer)).To(Succeed())
+			Expect(buffer.String()).To(HavePrefix("NAME          RESTARTS   UPTIME\n"))
+		})
+
+		It("should not configure other printers", func() {
+			Expect(columns.Apply(&printers.JSONPrinter{})).To(BeFalse())
+		})
+	})
+})
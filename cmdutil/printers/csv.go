@@ -30,7 +30,7 @@ import (
 	"github.com/lainio/err2/try"
 )
 
-var _ ObjectPrinter = (*CSVPrinter)(nil)
+var _ StreamingPrinter = (*CSVPrinter)(nil)
 
 type CSVPrinter struct {
 	PrintOptions
@@ -49,10 +49,55 @@ func (p *CSVPrinter) PrintObj(a any, w io.Writer) (err error) {
 	return enc.Error()
 }
 
+// NewStream implements StreamingPrinter. The headers of the first object written to the stream
+// are printed once, and every row is flushed to the underlying [io.Writer] as soon as it is
+// written.
+func (p *CSVPrinter) NewStream(w io.Writer) ObjectStream {
+	return &csvStream{printer: p, enc: csv.NewWriter(w)}
+}
+
+type csvStream struct {
+	printer      *CSVPrinter
+	enc          *csv.Writer
+	columns      int
+	wroteHeaders bool
+}
+
+// Write implements ObjectStream.
+func (s *csvStream) Write(obj any) (err error) {
+	defer err2.Handle(&err, nil)
+	headers, rows := try.To2(s.printer.TableReflectorFunc(obj))
+	if !s.wroteHeaders {
+		if len(headers) == 0 {
+			return nil
+		}
+		s.columns = len(headers)
+		s.wroteHeaders = true
+		if !s.printer.NoHeaders {
+			try.To(s.enc.Write(headers))
+		}
+	}
+	for _, row := range alignRows(rows, s.columns) {
+		try.To(s.enc.Write(row))
+	}
+	return s.Flush()
+}
+
+// Flush implements ObjectStream.
+func (s *csvStream) Flush() error {
+	s.enc.Flush()
+	return s.enc.Error()
+}
+
+// Close implements ObjectStream.
+func (s *csvStream) Close() error {
+	return s.Flush()
+}
+
 func NewCSVPrinter(options PrintOptions) ObjectPrinter {
 	printer := &CSVPrinter{
 		PrintOptions:       options,
-		TableReflectorFunc: DefaultTableReflectorFunc,
+		TableReflectorFunc: defaultTableReflectorFunc(options),
 	}
 	return printer
 }
@@ -24,6 +24,7 @@ package printers_test
 
 import (
 	"bytes"
+	"encoding/json"
 
 	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
 
@@ -73,6 +74,41 @@ var _ = Describe("CSVPrinter", Label("unit"), func() {
 			Expect(buffer.String()).To(Equal("KEY,VALUE\nkey1,value1\nkey2,value2\n"))
 		})
 
+		It("should print wide fields only when Wide is set", func() {
+			obj := struct {
+				Key  string
+				Node string `header:"NODE,wide"`
+			}{
+				Key:  "key",
+				Node: "node",
+			}
+			Expect(printer.PrintObj(obj, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("KEY\nkey\n"))
+
+			buffer.Reset()
+			printer = printers.NewCSVPrinter(printers.PrintOptions{Wide: true})
+			Expect(printer.PrintObj(obj, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("KEY,NODE\nkey,node\n"))
+		})
+
+		It("should use a customized DefaultTableReflectorFunc", func() {
+			defaultTableReflectorFunc := printers.DefaultTableReflectorFunc
+			DeferCleanup(func() { printers.DefaultTableReflectorFunc = defaultTableReflectorFunc })
+			printers.DefaultTableReflectorFunc = func(any) ([]string, [][]string, error) {
+				return []string{"CUSTOM"}, [][]string{{"row"}}, nil
+			}
+			printer = printers.NewCSVPrinter(printers.PrintOptions{Wide: true})
+			Expect(printer.PrintObj(struct{ Key string }{Key: "key"}, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("CUSTOM\nrow\n"))
+		})
+
+		It("should print numbers decoded from JSON without an exponent", func() {
+			var data any
+			Expect(json.Unmarshal([]byte(`{"id":12345678,"size":1048576}`), &data)).To(Succeed())
+			Expect(printer.PrintObj([]any{data}, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("ID,SIZE\n12345678,1048576\n"))
+		})
+
 		It("should not print an invalid object", func() {
 			err := printer.PrintObj(func() {}, buffer)
 			Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+	"github.com/samber/lo"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// CustomColumnsNoneValue is printed in a custom column when its field spec matches nothing.
+const CustomColumnsNoneValue = "<none>"
+
+// CustomColumn is a single column of a custom-columns table, made of a header label and a
+// jsonpath field spec (e.g. ".metadata.name") that is evaluated against each row's object.
+type CustomColumn struct {
+	Header    string
+	FieldSpec string
+}
+
+// ParseCustomColumns parses a custom-columns spec of the form "HEADER:FIELDSPEC,...", e.g.
+// "NAME:.metadata.name,AGE:.created".
+func ParseCustomColumns(spec string) ([]CustomColumn, error) {
+	if spec == "" {
+		return nil, fmt.Errorf("custom-columns format specified but no custom columns given")
+	}
+	parts := strings.Split(spec, ",")
+	columns := make([]CustomColumn, 0, len(parts))
+	for _, part := range parts {
+		header, fieldSpec, ok := strings.Cut(part, ":")
+		if !ok || header == "" {
+			return nil, fmt.Errorf(
+				"unexpected custom-columns spec: %q, expected <header>:<json-path-expr>",
+				part,
+			)
+		}
+		columns = append(columns, CustomColumn{Header: header, FieldSpec: fieldSpec})
+	}
+	return columns, nil
+}
+
+// ParseCustomColumnsFile parses a custom-columns template, which is made of a line of
+// whitespace-separated headers followed by a line of the same number of field specs, e.g.
+//
+//	NAME          AGE
+//	.metadata.name .created
+func ParseCustomColumnsFile(r io.Reader) (_ []CustomColumn, err error) {
+	defer err2.Handle(&err, nil)
+	scanner := bufio.NewScanner(r)
+	lines := make([][]string, 0, 2)
+	for scanner.Scan() && len(lines) < 2 {
+		if fields := strings.Fields(scanner.Text()); len(fields) > 0 {
+			lines = append(lines, fields)
+		}
+	}
+	try.To(scanner.Err())
+	if len(lines) != 2 {
+		return nil, fmt.Errorf("custom-columns template must have a header line and a field spec line")
+	}
+	if len(lines[0]) != len(lines[1]) {
+		return nil, fmt.Errorf(
+			"custom-columns template has %d headers but %d field specs",
+			len(lines[0]),
+			len(lines[1]),
+		)
+	}
+	return lo.Map(lines[0], func(header string, i int) CustomColumn {
+		return CustomColumn{Header: header, FieldSpec: lines[1][i]}
+	}), nil
+}
+
+// NewCustomColumnsReflectorFunc returns a TableReflectorFunc that resolves one column for each
+// of the given custom columns, instead of using struct field tags like GenerateTableData.
+//
+// Like JSONPathPrinter, field specs are evaluated against the JSON representation of the data.
+// If the data is a slice or array, each element becomes a row. Otherwise the data is a single
+// row.
+func NewCustomColumnsReflectorFunc(columns []CustomColumn) (TableReflectorFunc, error) {
+	parsers := make([]*jsonpath.JSONPath, 0, len(columns))
+	for _, column := range columns {
+		parser := jsonpath.New(column.Header).AllowMissingKeys(true)
+		if err := parser.Parse(relaxedJSONPathExpression(column.FieldSpec)); err != nil {
+			return nil, fmt.Errorf(
+				"error parsing field spec %q for column %q: %w",
+				column.FieldSpec,
+				column.Header,
+				err,
+			)
+		}
+		parsers = append(parsers, parser)
+	}
+	headers := lo.Map(columns, func(c CustomColumn, _ int) string { return c.Header })
+	return func(data any) (_ []string, rows [][]string, err error) {
+		defer err2.Handle(&err, nil)
+		rows = [][]string{}
+		value := try.To1(toJSONValue(data))
+		if value == nil {
+			return headers, rows, nil
+		}
+		items, ok := value.([]any)
+		if !ok {
+			items = []any{value}
+		}
+		for _, item := range items {
+			row := make([]string, 0, len(parsers))
+			for _, parser := range parsers {
+				row = append(row, formatCustomColumnResults(try.To1(parser.FindResults(item))))
+			}
+			rows = append(rows, row)
+		}
+		return headers, rows, nil
+	}, nil
+}
+
+// relaxedJSONPathExpression accepts field specs with or without the surrounding braces and
+// leading dot of a jsonpath expression, e.g. "metadata.name" -> "{.metadata.name}".
+func relaxedJSONPathExpression(fieldSpec string) string {
+	fieldSpec = strings.TrimSuffix(strings.TrimPrefix(fieldSpec, "{"), "}")
+	if !strings.HasPrefix(fieldSpec, ".") && !strings.HasPrefix(fieldSpec, "[") {
+		fieldSpec = "." + fieldSpec
+	}
+	return "{" + fieldSpec + "}"
+}
+
+func formatCustomColumnResults(results [][]reflect.Value) string {
+	values := []string{}
+	for _, result := range results {
+		for _, v := range result {
+			if v, ok := indirectValue(v); ok && v.IsValid() && v.CanInterface() {
+				values = append(values, fmt.Sprint(v.Interface()))
+			}
+		}
+	}
+	if len(values) == 0 {
+		return CustomColumnsNoneValue
+	}
+	return strings.Join(values, ",")
+}
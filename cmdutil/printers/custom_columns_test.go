@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Custom Columns", Label("unit"), func() {
+	type Metadata struct {
+		Name   string            `json:"name"`
+		Labels map[string]string `json:"labels,omitempty"`
+	}
+
+	type Item struct {
+		Metadata Metadata `json:"metadata"`
+		Size     int      `json:"size"`
+		Tags     []string `json:"tags,omitempty"`
+	}
+
+	items := []Item{
+		{Metadata: Metadata{Name: "foo", Labels: map[string]string{"app": "web"}}, Size: 42},
+		{Metadata: Metadata{Name: "bar"}, Size: 7, Tags: []string{"a", "b"}},
+	}
+
+	Describe("ParseCustomColumns", func() {
+		It("should parse headers and field specs", func() {
+			columns, err := printers.ParseCustomColumns("NAME:.metadata.name,SIZE:size")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(columns).To(Equal([]printers.CustomColumn{
+				{Header: "NAME", FieldSpec: ".metadata.name"},
+				{Header: "SIZE", FieldSpec: "size"},
+			}))
+		})
+
+		It("should return an error for an empty spec", func() {
+			_, err := printers.ParseCustomColumns("")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for a column without a field spec", func() {
+			_, err := printers.ParseCustomColumns("NAME:.metadata.name,SIZE")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ParseCustomColumnsFile", func() {
+		It("should parse a header line and a field spec line", func() {
+			columns, err := printers.ParseCustomColumnsFile(strings.NewReader(
+				"NAME            SIZE\n.metadata.name  .size\n",
+			))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(columns).To(Equal([]printers.CustomColumn{
+				{Header: "NAME", FieldSpec: ".metadata.name"},
+				{Header: "SIZE", FieldSpec: ".size"},
+			}))
+		})
+
+		It("should return an error when the lines have different lengths", func() {
+			_, err := printers.ParseCustomColumnsFile(strings.NewReader(
+				"NAME SIZE\n.metadata.name\n",
+			))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error when the field spec line is missing", func() {
+			_, err := printers.ParseCustomColumnsFile(strings.NewReader("NAME SIZE\n"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("NewCustomColumnsReflectorFunc", func() {
+		It("should return an error for an invalid field spec", func() {
+			_, err := printers.NewCustomColumnsReflectorFunc([]printers.CustomColumn{
+				{Header: "NAME", FieldSpec: ".metadata[name"},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should resolve a row for each element of a slice", func() {
+			reflector, err := printers.NewCustomColumnsReflectorFunc([]printers.CustomColumn{
+				{Header: "NAME", FieldSpec: ".metadata.name"},
+				{Header: "APP", FieldSpec: ".metadata.labels.app"},
+				{Header: "SIZE", FieldSpec: ".size"},
+				{Header: "TAGS", FieldSpec: ".tags[*]"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			headers, rows, err := reflector(items)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(headers).To(Equal([]string{"NAME", "APP", "SIZE", "TAGS"}))
+			Expect(rows).To(Equal([][]string{
+				{"foo", "web", "42", "<none>"},
+				{"bar", "<none>", "7", "a,b"},
+			}))
+		})
+
+		It("should resolve a single row for a struct", func() {
+			reflector, err := printers.NewCustomColumnsReflectorFunc([]printers.CustomColumn{
+				{Header: "NAME", FieldSpec: "{.metadata.name}"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			headers, rows, err := reflector(&items[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(headers).To(Equal([]string{"NAME"}))
+			Expect(rows).To(Equal([][]string{{"foo"}}))
+		})
+
+		It("should print large integers without an exponent", func() {
+			reflector, err := printers.NewCustomColumnsReflectorFunc([]printers.CustomColumn{
+				{Header: "SIZE", FieldSpec: ".size"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, rows, err := reflector(Item{Size: 12345678})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rows).To(Equal([][]string{{"12345678"}}))
+		})
+
+		It("should resolve no rows for nil", func() {
+			reflector, err := printers.NewCustomColumnsReflectorFunc([]printers.CustomColumn{
+				{Header: "NAME", FieldSpec: ".metadata.name"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, rows, err := reflector(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rows).To(BeEmpty())
+		})
+
+		It("should be usable by the CSVPrinter", func() {
+			reflector, err := printers.NewCustomColumnsReflectorFunc([]printers.CustomColumn{
+				{Header: "NAME", FieldSpec: ".metadata.name"},
+				{Header: "SIZE", FieldSpec: ".size"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			printer := &printers.CSVPrinter{TableReflectorFunc: reflector}
+			buffer := &bytes.Buffer{}
+			Expect(printer.PrintObj(items, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("NAME,SIZE\nfoo,42\nbar,7\n"))
+		})
+	})
+})
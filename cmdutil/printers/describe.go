@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var _ ObjectPrinter = (*DescribePrinter)(nil)
+
+// describeIndent is the indentation of nested sections and list items printed by DescribePrinter.
+const describeIndent = "  "
+
+// DescribePrinter prints objects vertically, as aligned "FIELD: value" lines, similar to
+// "kubectl describe".
+//
+// Fields are named with the same headers as GenerateTableData. Nested structs and maps are printed
+// as indented sections, and slices and arrays as indented lists. Values that implement one of the
+// interfaces supported by GenerateTableData (like fmt.Stringer) are always printed as text, and
+// nil or empty values are printed as "<none>".
+//
+// Slices, arrays, iterator functions and channels are printed as one block per element, separated
+// by blank lines.
+type DescribePrinter struct {
+	PrintOptions
+}
+
+// PrintObj implements ObjectPrinter.
+func (p *DescribePrinter) PrintObj(obj any, w io.Writer) error {
+	var sb strings.Builder
+	err := rangeElements(obj, func(elem any) error {
+		v, ok := indirectValue(reflect.ValueOf(elem))
+		if !ok || !v.IsValid() {
+			return nil
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(p.describe(v))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+// describeField is a "FIELD: value" line of a section. The value is invalid if it is nil.
+type describeField struct {
+	name  string
+	value reflect.Value
+	// format optionally formats the value, from the "format" header tag option of its field.
+	format cellFormatter
+}
+
+// describe returns the lines of a non-nil value: a section for structs and maps, a list for slices
+// and arrays, and text for anything else, with times formatted by [PrintOptions.TimeFormat].
+func (p *DescribePrinter) describe(v reflect.Value) string {
+	switch {
+	case v.Kind() == reflect.Struct && isNestedValue(v):
+		columns := nestedColumnExtract(v.Type(), p.PrintOptions)
+		fields := make([]describeField, 0, len(columns))
+		for _, c := range columns {
+			fv, _ := fieldByIndex(v, c.index)
+			fields = append(fields, describeField{name: c.header, value: fv, format: c.format})
+		}
+		return p.section(fields)
+	case isMapValue(v):
+		keys := v.MapKeys()
+		fields := make([]describeField, 0, len(keys))
+		for _, key := range keys {
+			fields = append(fields, describeField{
+				name:  fmt.Sprint(key.Interface()),
+				value: v.MapIndex(key),
+			})
+		}
+		sort.SliceStable(fields, func(i, j int) bool { return fields[i].name < fields[j].name })
+		return p.section(fields)
+	case isNestedValue(v):
+		var sb strings.Builder
+		for i := 0; i < v.Len(); i++ {
+			ev, ok := indirectValue(v.Index(i))
+			if !ok || !ev.IsValid() {
+				sb.WriteString("- " + CustomColumnsNoneValue + "\n")
+				continue
+			}
+			sb.WriteString(indentLines(p.describe(ev), "- ", describeIndent))
+		}
+		return sb.String()
+	default:
+		return p.TimeFormat.cellFormatter(resolveCellValue, false)(v) + "\n"
+	}
+}
+
+// section returns the lines of a list of fields, with their values aligned.
+func (p *DescribePrinter) section(fields []describeField) string {
+	width := 0
+	for _, f := range fields {
+		width = max(width, displayWidth(f.name)+1)
+	}
+
+	var sb strings.Builder
+	for _, f := range fields {
+		label := f.name + ":"
+		v, ok := indirectValue(f.value)
+		switch {
+		case !ok || !v.IsValid() || (isDescribeCollection(v) && v.Kind() != reflect.Struct && v.Len() == 0):
+			sb.WriteString(alignDescribeValue(label, width, CustomColumnsNoneValue))
+		case f.format != nil:
+			sb.WriteString(alignDescribeValue(label, width, f.format(v)))
+		case isDescribeCollection(v):
+			sb.WriteString(label + "\n")
+			sb.WriteString(indentLines(p.describe(v), describeIndent, describeIndent))
+		default:
+			sb.WriteString(alignDescribeValue(label, width, strings.TrimSuffix(p.describe(v), "\n")))
+		}
+	}
+	return sb.String()
+}
+
+// alignDescribeValue returns a "FIELD: value" line, with the value aligned to the given label
+// width. The lines of multi-line values are aligned with the first one.
+func alignDescribeValue(label string, width int, value string) string {
+	if value == "" {
+		return label + "\n"
+	}
+	padding := strings.Repeat(" ", width-displayWidth(label)+1)
+	return indentLines(value+"\n", label+padding, strings.Repeat(" ", width+1))
+}
+
+// isDescribeCollection reports whether v is printed as a nested section or list.
+func isDescribeCollection(v reflect.Value) bool {
+	return isNestedValue(v) || isMapValue(v)
+}
+
+// indentLines prefixes the first line of s with first, and every other line with rest.
+func indentLines(s, first, rest string) string {
+	lines := strings.SplitAfter(s, "\n")
+	var sb strings.Builder
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		if i == 0 {
+			sb.WriteString(first)
+		} else if line != "\n" {
+			sb.WriteString(rest)
+		}
+		sb.WriteString(line)
+	}
+	return sb.String()
+}
+
+func NewDescribePrinter(options PrintOptions) ObjectPrinter {
+	return &DescribePrinter{PrintOptions: options}
+}
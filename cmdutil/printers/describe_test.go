@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DescribePrinter", Label("unit"), func() {
+	type Port struct {
+		Name string `json:"name"`
+		Port int    `json:"port"`
+	}
+	type Owner struct {
+		Team  string
+		Email string `header:"E-MAIL"`
+	}
+	type Service struct {
+		Name        string            `json:"name"`
+		Description string            `json:"description"`
+		Owner       *Owner            `json:"owner"`
+		Labels      map[string]string `json:"labels"`
+		Ports       []Port            `json:"ports"`
+		Tags        []string          `json:"tags"`
+		Created     time.Time         `json:"created"`
+		Node        string            `header:"NODE,wide"`
+		Secret      string            `header:"-"`
+	}
+
+	var (
+		printer printers.ObjectPrinter
+		buffer  *bytes.Buffer
+		service Service
+	)
+
+	BeforeEach(func() {
+		printer = printers.NewDescribePrinter(printers.PrintOptions{})
+		buffer = new(bytes.Buffer)
+		service = Service{
+			Name:        "web",
+			Description: "line 1\nline 2",
+			Owner:       &Owner{Team: "infra", Email: "infra@example.com"},
+			Labels:      map[string]string{"tier": "frontend", "app": "web"},
+			Ports:       []Port{{"http", 80}, {"https", 443}},
+			Tags:        []string{"a", "b"},
+			Created:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Node:        "node-1",
+			Secret:      "hunter2",
+		}
+	})
+
+	It("should print fields vertically with nested sections and lists", func() {
+		Expect(printer.PrintObj(service, buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal(`NAME:        web
+DESCRIPTION: line 1
+             line 2
+OWNER:
+  TEAM:   infra
+  E-MAIL: infra@example.com
+LABELS:
+  app:  web
+  tier: frontend
+PORTS:
+  - NAME: http
+    PORT: 80
+  - NAME: https
+    PORT: 443
+TAGS:
+  - a
+  - b
+CREATED:     2024-01-02 03:04:05 +0000 UTC
+`))
+	})
+
+	It("should print nil and empty values as none", func() {
+		Expect(printer.PrintObj(Service{Name: "web"}, buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal(`NAME:        web
+DESCRIPTION:
+OWNER:       <none>
+LABELS:      <none>
+PORTS:       <none>
+TAGS:        <none>
+CREATED:     0001-01-01 00:00:00 +0000 UTC
+`))
+	})
+
+	It("should include wide fields in wide output", func() {
+		printer = printers.NewDescribePrinter(printers.PrintOptions{Wide: true})
+		Expect(printer.PrintObj(service, buffer)).To(Succeed())
+		Expect(buffer.String()).To(HaveSuffix("NODE:        node-1\n"))
+	})
+
+	It("should print each element of a slice as a separate block", func() {
+		Expect(printer.PrintObj([]*Port{{"http", 80}, nil, {"https", 443}}, buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal("NAME: http\nPORT: 80\n\nNAME: https\nPORT: 443\n"))
+	})
+
+	It("should print numbers decoded from JSON without an exponent", func() {
+		var data any
+		Expect(json.Unmarshal([]byte(`{"id":12345678,"size":1048576}`), &data)).To(Succeed())
+		Expect(printer.PrintObj(data, buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal("id:   12345678\nsize: 1048576\n"))
+	})
+
+	It("should not print anything for a nil object", func() {
+		Expect(printer.PrintObj(nil, buffer)).To(Succeed())
+		Expect(buffer.String()).To(BeEmpty())
+	})
+})
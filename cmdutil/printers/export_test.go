@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"reflect"
+	"time"
+)
+
+// This file exposes unexported identifiers of the printers package to the specs of the
+// printers_test package.
+
+var (
+	// BenchmarkRows returns n rows of the struct type used to benchmark table plans.
+	BenchmarkRows = benchmarkRows
+	// LegacyGenerateTableData is the implementation of GenerateTableData from before table plans.
+	LegacyGenerateTableData = legacyGenerateTableData
+)
+
+// CachedTablePlan returns the cached table plan of the type of obj.
+func CachedTablePlan(obj any, options PrintOptions) any {
+	return cachedTablePlan(reflect.TypeOf(obj), options)
+}
+
+// SetNow makes the "age" formatter and relative times measure from now instead of the current
+// time, until the returned function is called.
+func SetNow(now time.Time) (restore func()) {
+	nowFunc = func() time.Time { return now }
+	return func() { nowFunc = time.Now }
+}
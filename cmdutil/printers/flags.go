@@ -51,6 +51,14 @@ type FlaggablePrinter interface {
 type PrintFlags struct {
 	RegisteredPrintFlaggers []FlaggablePrinter
 	OutputFormat            *string
+	// Watch configures ToPrinter to wrap the selected printer in a WatchPrinter, so that repeated
+	// calls to PrintObj append to the same output. It is nil by default, and the --watch flag is
+	// only added for commands that set it.
+	Watch *bool
+	// TimeFormat holds the --timezone and --time-format flags. NewPrintFlags shares it with the
+	// registered YamlJSONPrinterFlags, and it should be shared with any other FlaggablePrinter that
+	// supports it (like TableCSVPrinterFlags) so that the flags apply to every output format.
+	TimeFormat *TimeFormatFlags
 
 	// OutputFlagSpecified indicates whether the user specifically requested a certain kind of
 	// output. using this function allows a sophisticated caller to change the flag binding logic
@@ -69,11 +77,22 @@ func (f *PrintFlags) AllowedFormats() []string {
 	)
 }
 
+// ToPrinter returns the printer of the first registered FlaggablePrinter that supports the
+// selected output format.
+//
+// Output formats may carry an argument using the "format=argument" syntax (e.g.
+// "jsonpath={.name}"), in which case the whole value is passed to each FlaggablePrinter.
+//
+// If Watch is set, the printer is wrapped in a WatchPrinter, which callers should close once they
+// are done printing.
 func (f *PrintFlags) ToPrinter() (ObjectPrinter, error) {
 	for _, fp := range f.RegisteredPrintFlaggers {
 		if p, err := fp.ToPrinter(lo.FromPtrOr(f.OutputFormat, "")); !IsNoCompatiblePrinterError(
 			err,
 		) {
+			if err == nil && lo.FromPtr(f.Watch) {
+				return NewWatchPrinter(p), nil
+			}
 			return p, err
 		}
 	}
@@ -89,6 +108,7 @@ func (f *PrintFlags) AddFlags(cmd *cobra.Command) {
 	lo.ForEach(f.RegisteredPrintFlaggers, func(rp FlaggablePrinter, _ int) {
 		rp.AddFlags(cmd)
 	})
+	f.TimeFormat.AddFlags(cmd)
 	if f.OutputFormat != nil {
 		cmd.Flags().StringVarP(
 			f.OutputFormat,
@@ -103,6 +123,15 @@ func (f *PrintFlags) AddFlags(cmd *cobra.Command) {
 			}
 		}
 	}
+	if f.Watch != nil {
+		cmd.Flags().BoolVarP(
+			f.Watch,
+			"watch",
+			"w",
+			*f.Watch,
+			"After printing the requested objects, keep printing changes to them as they happen.",
+		)
+	}
 }
 
 // WithDefaultOutput sets a default output format if one is not provided through a flag value.
@@ -112,12 +141,33 @@ func (f *PrintFlags) WithDefaultOutput(format string) *PrintFlags {
 }
 
 func NewPrintFlags() *PrintFlags {
+	allowMissingTemplateKeys := lo.ToPtr(true)
+	timeFormat := &TimeFormatFlags{
+		Timezone:   lo.ToPtr(""),
+		TimeFormat: lo.ToPtr(""),
+	}
 	return &PrintFlags{
 		OutputFormat: lo.ToPtr(""),
+		TimeFormat:   timeFormat,
 		RegisteredPrintFlaggers: []FlaggablePrinter{
 			&YamlJSONPrinterFlags{
 				JSONIndent: lo.ToPtr(false),
+				TimeFormat: timeFormat,
+			},
+			&JSONPathPrinterFlags{
+				AllowMissingKeys: allowMissingTemplateKeys,
+			},
+			&TemplatePrinterFlags{
+				TemplateArgument: lo.ToPtr(""),
+				AllowMissingKeys: allowMissingTemplateKeys,
 			},
 		},
 	}
 }
+
+// splitOutputFormat splits an output format of the form "format=argument" into its format name
+// and argument. The argument is empty if the output format does not have one.
+func splitOutputFormat(outputFormat string) (format, argument string) {
+	format, argument, _ = strings.Cut(outputFormat, "=")
+	return format, argument
+}
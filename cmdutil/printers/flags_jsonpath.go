@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+var _ FlaggablePrinter = (*JSONPathPrinterFlags)(nil)
+
+// JSONPathPrinterFlags provides the "jsonpath" and "jsonpath-file" output formats, which take
+// their template as an argument to the format, e.g. "-o jsonpath={.name}" or
+// "-o jsonpath-file=template.txt".
+type JSONPathPrinterFlags struct {
+	AllowMissingKeys *bool
+}
+
+// AddFlags implements FlaggablePrinter.
+func (j *JSONPathPrinterFlags) AddFlags(cmd *cobra.Command) {
+	if j.AllowMissingKeys != nil && cmd.Flags().Lookup("allow-missing-template-keys") == nil {
+		cmd.Flags().BoolVar(
+			j.AllowMissingKeys,
+			"allow-missing-template-keys",
+			lo.FromPtrOr(j.AllowMissingKeys, true),
+			"If true, ignore any errors in templates when a field or map key is missing in the template.",
+		)
+	}
+}
+
+// AllowedFormats implements FlaggablePrinter.
+func (j *JSONPathPrinterFlags) AllowedFormats() []string {
+	return []string{"jsonpath", "jsonpath-file"}
+}
+
+// ToPrinter implements FlaggablePrinter.
+func (j *JSONPathPrinterFlags) ToPrinter(format string) (ObjectPrinter, error) {
+	name, template := splitOutputFormat(format)
+	switch name {
+	case "jsonpath":
+	case "jsonpath-file":
+		if template != "" {
+			data, err := os.ReadFile(template)
+			if err != nil {
+				return nil, fmt.Errorf("error reading jsonpath file %q: %w", template, err)
+			}
+			template = string(data)
+		}
+	default:
+		return nil, NoCompatiblePrinterError{
+			OutputFormat:   lo.ToPtr(format),
+			AllowedFormats: j.AllowedFormats(),
+		}
+	}
+	if template == "" {
+		return nil, fmt.Errorf("output format %q specified but no template given", name)
+	}
+	p, err := NewJSONPathPrinter(template)
+	if err != nil {
+		return nil, err
+	}
+	p.AllowMissingKeys(lo.FromPtrOr(j.AllowMissingKeys, true))
+	return p, nil
+}
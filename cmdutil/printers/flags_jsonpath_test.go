@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSONPathPrinterFlags", Label("unit"), func() {
+	var (
+		j *printers.JSONPathPrinterFlags
+	)
+
+	BeforeEach(func() {
+		j = &printers.JSONPathPrinterFlags{}
+	})
+
+	Describe("AddFlags", func() {
+		It("should add allow-missing-template-keys flag when AllowMissingKeys is not nil", func() {
+			j.AllowMissingKeys = lo.ToPtr(true)
+			cmd := &cobra.Command{}
+			j.AddFlags(cmd)
+			flag := cmd.Flag("allow-missing-template-keys")
+			Expect(flag).ToNot(BeNil())
+			Expect(flag.Value.String()).To(Equal("true"))
+		})
+
+		It("should not add allow-missing-template-keys flag when AllowMissingKeys is nil", func() {
+			cmd := &cobra.Command{}
+			j.AddFlags(cmd)
+			Expect(cmd.Flag("allow-missing-template-keys")).To(BeNil())
+		})
+	})
+
+	Describe("AllowedFormats", func() {
+		It("should return jsonpath and jsonpath-file as allowed formats", func() {
+			Expect(j.AllowedFormats()).To(ConsistOf("jsonpath", "jsonpath-file"))
+		})
+	})
+
+	Describe("ToPrinter", func() {
+		It("should return a JSONPathPrinter when format is jsonpath with a template", func() {
+			printer, err := j.ToPrinter("jsonpath={.name}")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(BeAssignableToTypeOf(&printers.JSONPathPrinter{}))
+		})
+
+		It("should read the template from a file when format is jsonpath-file", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "template.txt")
+			Expect(os.WriteFile(path, []byte("{.name}"), 0o600)).To(Succeed())
+			printer, err := j.ToPrinter("jsonpath-file=" + path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(BeAssignableToTypeOf(&printers.JSONPathPrinter{}))
+		})
+
+		It("should return an error when the template file does not exist", func() {
+			_, err := j.ToPrinter("jsonpath-file=" + filepath.Join(GinkgoT().TempDir(), "missing"))
+			Expect(err).To(HaveOccurred())
+			Expect(printers.IsNoCompatiblePrinterError(err)).To(BeFalse())
+		})
+
+		It("should return an error when no template is given", func() {
+			_, err := j.ToPrinter("jsonpath")
+			Expect(err).To(HaveOccurred())
+			Expect(printers.IsNoCompatiblePrinterError(err)).To(BeFalse())
+		})
+
+		It("should return an error when the template is invalid", func() {
+			_, err := j.ToPrinter("jsonpath={.name")
+			Expect(err).To(HaveOccurred())
+			Expect(printers.IsNoCompatiblePrinterError(err)).To(BeFalse())
+		})
+
+		It("should return error when format is not supported", func() {
+			printer, err := j.ToPrinter("json")
+			Expect(printer).To(BeNil())
+			Expect(err).To(MatchError(printers.NoCompatiblePrinterError{
+				OutputFormat:   lo.ToPtr("json"),
+				AllowedFormats: []string{"jsonpath", "jsonpath-file"},
+			}))
+		})
+	})
+})
@@ -23,6 +23,9 @@
 package printers
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 )
@@ -31,10 +34,27 @@ var _ FlaggablePrinter = (*TableCSVPrinterFlags)(nil)
 
 type TableCSVPrinterFlags struct {
 	NoHeaders *bool
+	// ShowKind prepends a KIND column with the type of every row (see [PrintOptions.ShowKind]).
+	ShowKind *bool
+	// Expanded configures when table output prints records vertically. It must be one of the
+	// ExpandedModes.
+	Expanded *string
+	// MaxColumnWidth is the maximum width of every column of table output, or zero for no maximum.
+	MaxColumnWidth *int
+	// HTMLStandalone configures the "html" output format to print a complete HTML page.
+	HTMLStandalone *bool
+	// CellStyles are CellStyleRules for table output, in the format of ParseCellStyleRule.
+	CellStyles *[]string
+	// CellStylesFile is the path of a config file of CellStyleRules for table output, in the format
+	// of LoadCellStyleRules. Its rules are applied before CellStyles.
+	CellStylesFile *string
+	// TimeFormat configures how times are printed, see TimeFormatFlags.
+	TimeFormat *TimeFormatFlags
 }
 
 // AddFlags implements FlaggablePrinter.
 func (t *TableCSVPrinterFlags) AddFlags(cmd *cobra.Command) {
+	t.TimeFormat.AddFlags(cmd)
 	if t.NoHeaders != nil {
 		cmd.Flags().BoolVar(
 			t.NoHeaders,
@@ -43,24 +63,143 @@ func (t *TableCSVPrinterFlags) AddFlags(cmd *cobra.Command) {
 			"When using the default table output, don't print headers (default print headers).",
 		)
 	}
+	if t.ShowKind != nil {
+		cmd.Flags().BoolVar(
+			t.ShowKind,
+			"show-kind",
+			lo.FromPtrOr(t.ShowKind, false),
+			"When using table output, print the kind of every row in a KIND column.",
+		)
+	}
+	if t.Expanded != nil {
+		cmd.Flags().StringVar(
+			t.Expanded,
+			"expanded",
+			lo.FromPtrOr(t.Expanded, string(ExpandedOff)),
+			"When using table output, print each record vertically. One of: (auto, on, off). "+
+				"auto only does so when the table is wider than the terminal.",
+		)
+	}
+	if t.MaxColumnWidth != nil {
+		cmd.Flags().IntVar(
+			t.MaxColumnWidth,
+			"max-col-width",
+			lo.FromPtrOr(t.MaxColumnWidth, 0),
+			"When using table output, truncate columns wider than this many characters (default no maximum).",
+		)
+	}
+	if t.HTMLStandalone != nil {
+		cmd.Flags().BoolVar(
+			t.HTMLStandalone,
+			"html-standalone",
+			lo.FromPtrOr(t.HTMLStandalone, false),
+			"When using the \"html\" output format, print a complete HTML page (default print only the table).",
+		)
+	}
+	if t.CellStyles != nil {
+		cmd.Flags().StringArrayVar(
+			t.CellStyles,
+			"cell-style",
+			lo.FromPtrOr(t.CellStyles, nil),
+			"When using table output, style the cells of a column that match a condition, "+
+				"like STATUS=Failed:red or CPU>=90:red+bold. May be repeated.",
+		)
+	}
+	if t.CellStylesFile != nil {
+		cmd.Flags().StringVar(
+			t.CellStylesFile,
+			"cell-styles-file",
+			lo.FromPtrOr(t.CellStylesFile, ""),
+			"When using table output, style cells with the rules of a YAML file.",
+		)
+	}
 }
 
 // AllowedFormats implements FlaggablePrinter.
 func (t *TableCSVPrinterFlags) AllowedFormats() []string {
-	return []string{"csv", "table"}
+	return []string{
+		"csv",
+		"table",
+		"wide",
+		"custom-columns",
+		"custom-columns-file",
+		"csv-file",
+		"markdown",
+		"html",
+		"plain",
+		"describe",
+	}
 }
 
 // ToPrinter implements FlaggablePrinter.
+//
+// Like custom-columns, the csv format accepts a custom-columns spec ("csv=NAME:.name,...") or
+// file ("csv-file=<path>") to choose its columns at runtime.
 func (t *TableCSVPrinterFlags) ToPrinter(format string) (ObjectPrinter, error) {
+	if name, _ := splitOutputFormat(format); !lo.Contains(t.AllowedFormats(), name) {
+		return nil, NoCompatiblePrinterError{
+			OutputFormat:   lo.ToPtr(format),
+			AllowedFormats: t.AllowedFormats(),
+		}
+	}
+	timeFormat, err := t.TimeFormat.ToTimeFormat()
+	if err != nil {
+		return nil, err
+	}
+	options := PrintOptions{
+		NoHeaders:  lo.FromPtrOr(t.NoHeaders, false),
+		ShowKind:   lo.FromPtrOr(t.ShowKind, false),
+		TimeFormat: timeFormat,
+	}
 	switch format {
 	case "csv":
-		return NewCSVPrinter(PrintOptions{
-			NoHeaders: lo.FromPtrOr(t.NoHeaders, false),
-		}), nil
+		return NewCSVPrinter(options), nil
 	case "table":
-		return NewTablePrinter(PrintOptions{
-			NoHeaders: lo.FromPtrOr(t.NoHeaders, false),
-		}), nil
+		return t.newTablePrinter(options)
+	case "wide":
+		options.Wide = true
+		return t.newTablePrinter(options)
+	case "markdown":
+		return NewMarkdownPrinter(options), nil
+	case "plain":
+		return NewPlainPrinter(options), nil
+	case "describe":
+		return NewDescribePrinter(options), nil
+	case "html":
+		printer := &HTMLPrinter{
+			PrintOptions: options,
+			Standalone:   lo.FromPtrOr(t.HTMLStandalone, false),
+			Style:        DefaultHTMLStyle,
+		}
+		return printer, nil
+	}
+	switch name, argument := splitOutputFormat(format); name {
+	case "custom-columns", "custom-columns-file":
+		columns, err := customColumnsFromFormat(name, argument)
+		if err != nil {
+			return nil, err
+		}
+		reflector, err := NewCustomColumnsReflectorFunc(columns)
+		if err != nil {
+			return nil, err
+		}
+		printer, err := t.newTablePrinter(options)
+		if err != nil {
+			return nil, err
+		}
+		printer.TableReflectorFunc = reflector
+		printer.ColumnLayoutFunc = nil
+		return printer, nil
+	case "csv", "csv-file":
+		columns, err := customColumnsFromFormat(name, argument)
+		if err != nil {
+			return nil, err
+		}
+		reflector, err := NewCustomColumnsReflectorFunc(columns)
+		if err != nil {
+			return nil, err
+		}
+		return &CSVPrinter{PrintOptions: options, TableReflectorFunc: reflector}, nil
 	default:
 		return nil, NoCompatiblePrinterError{
 			OutputFormat:   lo.ToPtr(format),
@@ -68,3 +207,60 @@ func (t *TableCSVPrinterFlags) ToPrinter(format string) (ObjectPrinter, error) {
 		}
 	}
 }
+
+func (t *TableCSVPrinterFlags) newTablePrinter(options PrintOptions) (*TablePrinter, error) {
+	expanded, err := ParseExpandedMode(lo.FromPtrOr(t.Expanded, ""))
+	if err != nil {
+		return nil, err
+	}
+	rules, err := t.cellStyleRules()
+	if err != nil {
+		return nil, err
+	}
+	printer := newTablePrinter(options)
+	printer.Expanded = expanded
+	printer.MaxColumnWidth = lo.FromPtrOr(t.MaxColumnWidth, 0)
+	printer.CellStyleRules = rules
+	return printer, nil
+}
+
+// cellStyleRules returns the rules of CellStylesFile, followed by the rules of CellStyles.
+func (t *TableCSVPrinterFlags) cellStyleRules() ([]CellStyleRule, error) {
+	var rules []CellStyleRule
+	if path := lo.FromPtrOr(t.CellStylesFile, ""); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cell styles file %q: %w", path, err)
+		}
+		defer f.Close()
+		if rules, err = LoadCellStyleRules(f); err != nil {
+			return nil, fmt.Errorf("error reading cell styles file %q: %w", path, err)
+		}
+	}
+	for _, spec := range lo.FromPtrOr(t.CellStyles, nil) {
+		rule, err := ParseCellStyleRule(spec)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// customColumnsFromFormat parses the custom columns of a "custom-columns=<spec>" or "csv=<spec>"
+// output format, or reads them from the file of a "custom-columns-file=<path>" or
+// "csv-file=<path>" output format.
+func customColumnsFromFormat(format, argument string) ([]CustomColumn, error) {
+	if format == "custom-columns" || format == "csv" {
+		return ParseCustomColumns(argument)
+	}
+	if argument == "" {
+		return nil, fmt.Errorf("%s format specified but no file given", format)
+	}
+	f, err := os.Open(argument)
+	if err != nil {
+		return nil, fmt.Errorf("error reading custom-columns file %q: %w", argument, err)
+	}
+	defer f.Close()
+	return ParseCustomColumnsFile(f)
+}
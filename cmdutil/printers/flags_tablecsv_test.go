@@ -23,6 +23,10 @@
 package printers_test
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+
 	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
@@ -51,6 +55,36 @@ var _ = Describe("TableCSVPrinterFlags", Label("unit"), func() {
 			Expect(flag.Value.String()).To(Equal("false"))
 		})
 
+		It("should add expanded flag when Expanded is not nil", func() {
+			tableCSVPrinterFlags.Expanded = lo.ToPtr("auto")
+			cmd := &cobra.Command{}
+			tableCSVPrinterFlags.AddFlags(cmd)
+			flag := cmd.Flag("expanded")
+			Expect(flag).ToNot(BeNil())
+			Expect(flag.Value.String()).To(Equal("auto"))
+		})
+
+		It("should add show-kind flag when ShowKind is not nil", func() {
+			tableCSVPrinterFlags.ShowKind = lo.ToPtr(false)
+			cmd := &cobra.Command{}
+			tableCSVPrinterFlags.AddFlags(cmd)
+			Expect(cmd.Flag("show-kind")).ToNot(BeNil())
+		})
+
+		It("should add max-col-width flag when MaxColumnWidth is not nil", func() {
+			tableCSVPrinterFlags.MaxColumnWidth = lo.ToPtr(0)
+			cmd := &cobra.Command{}
+			tableCSVPrinterFlags.AddFlags(cmd)
+			Expect(cmd.Flag("max-col-width")).ToNot(BeNil())
+		})
+
+		It("should add html-standalone flag when HTMLStandalone is not nil", func() {
+			tableCSVPrinterFlags.HTMLStandalone = lo.ToPtr(false)
+			cmd := &cobra.Command{}
+			tableCSVPrinterFlags.AddFlags(cmd)
+			Expect(cmd.Flag("html-standalone")).ToNot(BeNil())
+		})
+
 		It("should not add no-headers flag when NoHeaders is nil", func() {
 			tableCSVPrinterFlags.NoHeaders = nil
 			cmd := &cobra.Command{}
@@ -61,9 +95,20 @@ var _ = Describe("TableCSVPrinterFlags", Label("unit"), func() {
 	})
 
 	Describe("AllowedFormats", func() {
-		It("should return csv and table as allowed formats", func() {
+		It("should return the table, csv, markdown, html, plain and describe formats as allowed formats", func() {
 			formats := tableCSVPrinterFlags.AllowedFormats()
-			Expect(formats).To(ConsistOf("csv", "table"))
+			Expect(formats).To(ConsistOf(
+				"csv",
+				"table",
+				"wide",
+				"custom-columns",
+				"custom-columns-file",
+				"csv-file",
+				"markdown",
+				"html",
+				"plain",
+				"describe",
+			))
 		})
 	})
 
@@ -80,13 +125,137 @@ var _ = Describe("TableCSVPrinterFlags", Label("unit"), func() {
 			Expect(printer).To(BeAssignableToTypeOf(&printers.TablePrinter{}))
 		})
 
+		It("should return a wide TablePrinter when format is wide", func() {
+			printer, err := tableCSVPrinterFlags.ToPrinter("wide")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(BeAssignableToTypeOf(&printers.TablePrinter{}))
+			Expect(printer).To(HaveField("PrintOptions.Wide", BeTrue()))
+		})
+
+		It("should return an expanded TablePrinter when Expanded is set", func() {
+			tableCSVPrinterFlags.Expanded = lo.ToPtr("on")
+			printer, err := tableCSVPrinterFlags.ToPrinter("wide")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(HaveField("Expanded", printers.ExpandedOn))
+		})
+
+		It("should return a TablePrinter with a maximum column width when MaxColumnWidth is set", func() {
+			tableCSVPrinterFlags.MaxColumnWidth = lo.ToPtr(30)
+			printer, err := tableCSVPrinterFlags.ToPrinter("table")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(HaveField("MaxColumnWidth", 30))
+		})
+
+		It("should return a TablePrinter that shows kinds when ShowKind is set", func() {
+			tableCSVPrinterFlags.ShowKind = lo.ToPtr(true)
+			printer, err := tableCSVPrinterFlags.ToPrinter("table")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(HaveField("PrintOptions.ShowKind", BeTrue()))
+		})
+
+		It("should return error when Expanded is invalid", func() {
+			tableCSVPrinterFlags.Expanded = lo.ToPtr("sometimes")
+			printer, err := tableCSVPrinterFlags.ToPrinter("table")
+			Expect(err).To(MatchError(ContainSubstring("invalid expanded mode")))
+			Expect(printer).To(BeNil())
+		})
+
+		It("should return MarkdownPrinter when format is markdown", func() {
+			printer, err := tableCSVPrinterFlags.ToPrinter("markdown")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(BeAssignableToTypeOf(&printers.MarkdownPrinter{}))
+		})
+
+		It("should return PlainPrinter when format is plain", func() {
+			printer, err := tableCSVPrinterFlags.ToPrinter("plain")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(BeAssignableToTypeOf(&printers.PlainPrinter{}))
+		})
+
+		It("should return DescribePrinter when format is describe", func() {
+			printer, err := tableCSVPrinterFlags.ToPrinter("describe")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(BeAssignableToTypeOf(&printers.DescribePrinter{}))
+		})
+
+		It("should return HTMLPrinter when format is html", func() {
+			tableCSVPrinterFlags.HTMLStandalone = lo.ToPtr(true)
+			printer, err := tableCSVPrinterFlags.ToPrinter("html")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(BeAssignableToTypeOf(&printers.HTMLPrinter{}))
+			Expect(printer).To(HaveField("Standalone", BeTrue()))
+		})
+
+		It("should return TablePrinter when format is custom-columns", func() {
+			printer, err := tableCSVPrinterFlags.ToPrinter("custom-columns=NAME:.name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(BeAssignableToTypeOf(&printers.TablePrinter{}))
+		})
+
+		It("should honor no-headers when format is custom-columns", func() {
+			tableCSVPrinterFlags.NoHeaders = lo.ToPtr(true)
+			printer, err := tableCSVPrinterFlags.ToPrinter("custom-columns=NAME:.name")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(HaveField("PrintOptions.NoHeaders", BeTrue()))
+		})
+
+		It("should return TablePrinter when format is custom-columns-file", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "columns.txt")
+			Expect(os.WriteFile(path, []byte("NAME\n.name\n"), 0o600)).To(Succeed())
+			printer, err := tableCSVPrinterFlags.ToPrinter("custom-columns-file=" + path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(BeAssignableToTypeOf(&printers.TablePrinter{}))
+		})
+
+		It("should return a CSVPrinter with custom columns when format is csv with a spec", func() {
+			tableCSVPrinterFlags.NoHeaders = lo.ToPtr(true)
+			printer, err := tableCSVPrinterFlags.ToPrinter("csv=NAME:.name,SIZE:.size")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(BeAssignableToTypeOf(&printers.CSVPrinter{}))
+			buffer := &bytes.Buffer{}
+			Expect(printer.PrintObj(map[string]any{"name": "foo", "size": 42}, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("foo,42\n"))
+		})
+
+		It("should return a CSVPrinter with custom columns when format is csv-file", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "columns.txt")
+			Expect(os.WriteFile(path, []byte("NAME\n.name\n"), 0o600)).To(Succeed())
+			printer, err := tableCSVPrinterFlags.ToPrinter("csv-file=" + path)
+			Expect(err).ToNot(HaveOccurred())
+			buffer := &bytes.Buffer{}
+			Expect(printer.PrintObj(map[string]any{"name": "foo"}, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("NAME\nfoo\n"))
+		})
+
+		It("should return error when csv-file is given no file", func() {
+			_, err := tableCSVPrinterFlags.ToPrinter("csv-file")
+			Expect(err).To(MatchError(ContainSubstring("csv-file format specified but no file given")))
+		})
+
+		It("should return error when custom columns are invalid", func() {
+			_, err := tableCSVPrinterFlags.ToPrinter("custom-columns=NAME")
+			Expect(err).To(HaveOccurred())
+			Expect(printers.IsNoCompatiblePrinterError(err)).To(BeFalse())
+		})
+
 		It("should return error when format is not supported", func() {
 			printer, err := tableCSVPrinterFlags.ToPrinter("unsupported")
 			Expect(err).To(HaveOccurred())
 			Expect(printer).To(BeNil())
 			Expect(err).To(MatchError(printers.NoCompatiblePrinterError{
-				OutputFormat:   lo.ToPtr("unsupported"),
-				AllowedFormats: []string{"csv", "table"},
+				OutputFormat: lo.ToPtr("unsupported"),
+				AllowedFormats: []string{
+					"csv",
+					"table",
+					"wide",
+					"custom-columns",
+					"custom-columns-file",
+					"csv-file",
+					"markdown",
+					"html",
+					"plain",
+					"describe",
+				},
 			}))
 		})
 	})
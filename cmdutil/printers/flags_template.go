@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+var _ FlaggablePrinter = (*TemplatePrinterFlags)(nil)
+
+// TemplatePrinterFlags provides the "go-template" and "go-template-file" output formats.
+//
+// The template may be given as an argument to the format, e.g. "-o go-template={{.name}}" or
+// "-o go-template-file=template.txt", or through the "--template" flag.
+type TemplatePrinterFlags struct {
+	// TemplateArgument is bound to the "--template" flag, and is used when the output format
+	// does not carry its own template argument.
+	TemplateArgument *string
+	// AllowMissingKeys is bound to the "--allow-missing-template-keys" flag. It may share its
+	// pointer with JSONPathPrinterFlags, in which case the flag is only added once.
+	AllowMissingKeys *bool
+}
+
+// AddFlags implements FlaggablePrinter.
+func (t *TemplatePrinterFlags) AddFlags(cmd *cobra.Command) {
+	if t.TemplateArgument != nil {
+		cmd.Flags().StringVar(
+			t.TemplateArgument,
+			"template",
+			lo.FromPtrOr(t.TemplateArgument, ""),
+			"Template string or path to template file to use when -o=go-template, -o=go-template-file.",
+		)
+		_ = cmd.MarkFlagFilename("template")
+	}
+	if t.AllowMissingKeys != nil && cmd.Flags().Lookup("allow-missing-template-keys") == nil {
+		cmd.Flags().BoolVar(
+			t.AllowMissingKeys,
+			"allow-missing-template-keys",
+			lo.FromPtrOr(t.AllowMissingKeys, true),
+			"If true, ignore any errors in templates when a field or map key is missing in the template.",
+		)
+	}
+}
+
+// AllowedFormats implements FlaggablePrinter.
+func (t *TemplatePrinterFlags) AllowedFormats() []string {
+	return []string{"go-template", "go-template-file"}
+}
+
+// ToPrinter implements FlaggablePrinter.
+func (t *TemplatePrinterFlags) ToPrinter(format string) (ObjectPrinter, error) {
+	name, template := splitOutputFormat(format)
+	if template == "" {
+		template = lo.FromPtrOr(t.TemplateArgument, "")
+	}
+	switch name {
+	case "go-template":
+	case "go-template-file":
+		if template != "" {
+			data, err := os.ReadFile(template)
+			if err != nil {
+				return nil, fmt.Errorf("error reading template file %q: %w", template, err)
+			}
+			template = string(data)
+		}
+	default:
+		return nil, NoCompatiblePrinterError{
+			OutputFormat:   lo.ToPtr(format),
+			AllowedFormats: t.AllowedFormats(),
+		}
+	}
+	if template == "" {
+		return nil, fmt.Errorf("output format %q specified but no template given", name)
+	}
+	p, err := NewGoTemplatePrinter(template)
+	if err != nil {
+		return nil, err
+	}
+	p.AllowMissingKeys(lo.FromPtrOr(t.AllowMissingKeys, true))
+	return p, nil
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TemplatePrinterFlags", Label("unit"), func() {
+	var (
+		t *printers.TemplatePrinterFlags
+	)
+
+	BeforeEach(func() {
+		t = &printers.TemplatePrinterFlags{}
+	})
+
+	Describe("AddFlags", func() {
+		It("should add template flags when their values are not nil", func() {
+			t.TemplateArgument = lo.ToPtr("")
+			t.AllowMissingKeys = lo.ToPtr(true)
+			cmd := &cobra.Command{}
+			t.AddFlags(cmd)
+			Expect(cmd.Flag("template")).ToNot(BeNil())
+			Expect(cmd.Flag("allow-missing-template-keys")).ToNot(BeNil())
+		})
+
+		It("should not add template flags when their values are nil", func() {
+			cmd := &cobra.Command{}
+			t.AddFlags(cmd)
+			Expect(cmd.Flag("template")).To(BeNil())
+			Expect(cmd.Flag("allow-missing-template-keys")).To(BeNil())
+		})
+
+		It("should not add allow-missing-template-keys flag twice", func() {
+			allow := lo.ToPtr(true)
+			t.AllowMissingKeys = allow
+			cmd := &cobra.Command{}
+			(&printers.JSONPathPrinterFlags{AllowMissingKeys: allow}).AddFlags(cmd)
+			Expect(func() { t.AddFlags(cmd) }).NotTo(Panic())
+			Expect(cmd.Flag("allow-missing-template-keys")).ToNot(BeNil())
+		})
+	})
+
+	Describe("AllowedFormats", func() {
+		It("should return go-template and go-template-file as allowed formats", func() {
+			Expect(t.AllowedFormats()).To(ConsistOf("go-template", "go-template-file"))
+		})
+	})
+
+	Describe("ToPrinter", func() {
+		It("should return a GoTemplatePrinter when format is go-template with a template", func() {
+			printer, err := t.ToPrinter("go-template={{.name}}")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(BeAssignableToTypeOf(&printers.GoTemplatePrinter{}))
+		})
+
+		It("should use the template flag when the format has no argument", func() {
+			t.TemplateArgument = lo.ToPtr("{{.name}}")
+			printer, err := t.ToPrinter("go-template")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(BeAssignableToTypeOf(&printers.GoTemplatePrinter{}))
+		})
+
+		It("should read the template from a file when format is go-template-file", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "template.txt")
+			Expect(os.WriteFile(path, []byte("{{.name}}"), 0o600)).To(Succeed())
+			printer, err := t.ToPrinter("go-template-file=" + path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(printer).To(BeAssignableToTypeOf(&printers.GoTemplatePrinter{}))
+		})
+
+		It("should return an error when no template is given", func() {
+			_, err := t.ToPrinter("go-template")
+			Expect(err).To(HaveOccurred())
+			Expect(printers.IsNoCompatiblePrinterError(err)).To(BeFalse())
+		})
+
+		It("should return error when format is not supported", func() {
+			printer, err := t.ToPrinter("json")
+			Expect(printer).To(BeNil())
+			Expect(err).To(MatchError(printers.NoCompatiblePrinterError{
+				OutputFormat:   lo.ToPtr("json"),
+				AllowedFormats: []string{"go-template", "go-template-file"},
+			}))
+		})
+	})
+})
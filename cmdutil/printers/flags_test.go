@@ -24,6 +24,7 @@ package printers_test
 
 import (
 	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -47,6 +48,18 @@ var _ = Describe("PrintFlags", Label("unit"), func() {
 			Expect(cmd.Flag("output")).NotTo(BeNil())
 		})
 
+		It("should not add watch flag to command by default", func() {
+			printFlags.AddFlags(cmd)
+			Expect(cmd.Flag("watch")).To(BeNil())
+		})
+
+		It("should add watch flag to command when Watch is not nil", func() {
+			printFlags.Watch = lo.ToPtr(false)
+			printFlags.AddFlags(cmd)
+			Expect(cmd.Flag("watch")).NotTo(BeNil())
+			Expect(cmd.Flags().ShorthandLookup("w")).To(Equal(cmd.Flag("watch")))
+		})
+
 		It("should set OutputFlagSpecified function", func() {
 			printFlags.AddFlags(cmd)
 			Expect(printFlags.OutputFlagSpecified).NotTo(BeNil())
@@ -68,6 +81,29 @@ var _ = Describe("PrintFlags", Label("unit"), func() {
 			Expect(printer).NotTo(BeNil())
 		})
 
+		It("should not return a watch printer by default", func() {
+			printFlags.WithDefaultOutput("json")
+			printer, err := printFlags.ToPrinter()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(printer).To(BeAssignableToTypeOf(&printers.JSONPrinter{}))
+		})
+
+		It("should return a printer if format takes an argument", func() {
+			printFlags.WithDefaultOutput("jsonpath={.name}")
+			printer, err := printFlags.ToPrinter()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(printer).To(BeAssignableToTypeOf(&printers.JSONPathPrinter{}))
+		})
+
+		It("should return a watch printer if watch is set", func() {
+			printFlags.WithDefaultOutput("json")
+			printFlags.Watch = lo.ToPtr(true)
+			printer, err := printFlags.ToPrinter()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(printer).To(BeAssignableToTypeOf(&printers.WatchPrinter{}))
+			Expect(printer).To(HaveField("Printer", BeAssignableToTypeOf(&printers.JSONPrinter{})))
+		})
+
 		It("should return an error if format is not supported", func() {
 			printFlags.WithDefaultOutput("unsupported")
 			_, err := printFlags.ToPrinter()
@@ -80,6 +116,7 @@ var _ = Describe("PrintFlags", Label("unit"), func() {
 			formats := printFlags.AllowedFormats()
 			Expect(formats).To(ContainElement("json"))
 			Expect(formats).To(ContainElement("yaml"))
+			Expect(formats).To(ContainElement("jsonpath"))
 		})
 	})
 })
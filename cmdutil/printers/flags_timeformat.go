@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+// TimeFormatFlags provides the --timezone and --time-format flags, which configure how printers
+// print time.Time values (see TimeFormat). A single TimeFormatFlags is meant to be shared by every
+// FlaggablePrinter that supports it, like the AllowMissingKeys flag of JSONPathPrinterFlags and
+// TemplatePrinterFlags, so that the flags apply to every output format.
+type TimeFormatFlags struct {
+	// Timezone is the name of the time zone that times are printed in, like "UTC" or "Local".
+	Timezone *string
+	// TimeFormat is one of the TimeLayouts or a layout for time.Time.Format.
+	TimeFormat *string
+}
+
+// AddFlags adds the flags of every non-nil field to the command, unless they were already added.
+func (f *TimeFormatFlags) AddFlags(cmd *cobra.Command) {
+	if f == nil {
+		return
+	}
+	if f.Timezone != nil && cmd.Flags().Lookup("timezone") == nil {
+		cmd.Flags().StringVar(
+			f.Timezone,
+			"timezone",
+			lo.FromPtrOr(f.Timezone, ""),
+			"Print times in this time zone, like UTC, Local or America/New_York (default the time zone of each time).",
+		)
+	}
+	if f.TimeFormat != nil && cmd.Flags().Lookup("time-format") == nil {
+		cmd.Flags().StringVar(
+			f.TimeFormat,
+			"time-format",
+			lo.FromPtrOr(f.TimeFormat, ""),
+			fmt.Sprintf(
+				"Print times in this format. One of: (%s), or a Go time layout like 2006-01-02 "+
+					"(default the format of the output).",
+				strings.Join(TimeLayouts, ", "),
+			),
+		)
+	}
+}
+
+// ToTimeFormat returns the TimeFormat of the flags. It returns the zero TimeFormat if f is nil.
+func (f *TimeFormatFlags) ToTimeFormat() (TimeFormat, error) {
+	if f == nil {
+		return TimeFormat{}, nil
+	}
+	return ParseTimeFormat(lo.FromPtrOr(f.Timezone, ""), lo.FromPtrOr(f.TimeFormat, ""))
+}
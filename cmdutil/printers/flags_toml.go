@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+)
+
+var _ FlaggablePrinter = (*TOMLPrinterFlags)(nil)
+
+// TOMLPrinterFlags provides the "toml" output format. It is not registered by NewPrintFlags, and
+// can be added to PrintFlags.RegisteredPrintFlaggers by commands that support it.
+type TOMLPrinterFlags struct{}
+
+// AddFlags implements FlaggablePrinter.
+func (t *TOMLPrinterFlags) AddFlags(_ *cobra.Command) {}
+
+// AllowedFormats implements FlaggablePrinter.
+func (t *TOMLPrinterFlags) AllowedFormats() []string {
+	return []string{"toml"}
+}
+
+// ToPrinter implements FlaggablePrinter.
+func (t *TOMLPrinterFlags) ToPrinter(format string) (ObjectPrinter, error) {
+	if format != "toml" {
+		return nil, NoCompatiblePrinterError{
+			OutputFormat:   lo.ToPtr(format),
+			AllowedFormats: t.AllowedFormats(),
+		}
+	}
+	return NewTOMLPrinter(), nil
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TOMLPrinterFlags", Label("unit"), func() {
+	var t *printers.TOMLPrinterFlags
+
+	BeforeEach(func() {
+		t = &printers.TOMLPrinterFlags{}
+	})
+
+	Describe("AddFlags", func() {
+		It("does not add any flags to the command", func() {
+			cmd := &cobra.Command{}
+			t.AddFlags(cmd)
+			Expect(cmd.Flags().HasFlags()).To(BeFalse())
+		})
+	})
+
+	Describe("AllowedFormats", func() {
+		It("returns toml as the allowed format", func() {
+			Expect(t.AllowedFormats()).To(Equal([]string{"toml"}))
+		})
+	})
+
+	Describe("ToPrinter", func() {
+		Context("when format is toml", func() {
+			It("returns a TOMLPrinter", func() {
+				printer, err := t.ToPrinter("toml")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(printer).To(BeAssignableToTypeOf(&printers.TOMLPrinter{}))
+			})
+		})
+
+		Context("when format is not toml", func() {
+			It("returns an error", func() {
+				_, err := t.ToPrinter("yaml")
+				Expect(err).To(MatchError(printers.NoCompatiblePrinterError{
+					OutputFormat:   lo.ToPtr("yaml"),
+					AllowedFormats: []string{"toml"},
+				}))
+			})
+		})
+
+		Context("when registered with PrintFlags", func() {
+			It("returns a TOMLPrinter", func() {
+				printFlags := printers.NewPrintFlags().WithDefaultOutput("toml")
+				printFlags.RegisteredPrintFlaggers = append(printFlags.RegisteredPrintFlaggers, t)
+				printer, err := printFlags.ToPrinter()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(printer).To(BeAssignableToTypeOf(&printers.TOMLPrinter{}))
+			})
+		})
+	})
+})
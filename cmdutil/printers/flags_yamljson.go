@@ -31,10 +31,13 @@ var _ FlaggablePrinter = (*YamlJSONPrinterFlags)(nil)
 
 type YamlJSONPrinterFlags struct {
 	JSONIndent *bool
+	// TimeFormat configures how times are printed, see TimeFormatFlags.
+	TimeFormat *TimeFormatFlags
 }
 
 // AddFlags implements FlaggablePrinter.
 func (y *YamlJSONPrinterFlags) AddFlags(cmd *cobra.Command) {
+	y.TimeFormat.AddFlags(cmd)
 	if y.JSONIndent != nil {
 		cmd.Flags().
 			BoolVar(
@@ -48,16 +51,30 @@ func (y *YamlJSONPrinterFlags) AddFlags(cmd *cobra.Command) {
 
 // AllowedFormats implements FlaggablePrinter.
 func (y *YamlJSONPrinterFlags) AllowedFormats() []string {
-	return []string{"json", "yaml"}
+	return []string{"json", "yaml", "jsonl", "ndjson", "yaml-stream"}
 }
 
 // ToPrinter implements FlaggablePrinter.
 func (y *YamlJSONPrinterFlags) ToPrinter(format string) (ObjectPrinter, error) {
+	if !lo.Contains(y.AllowedFormats(), format) {
+		return nil, NoCompatiblePrinterError{
+			OutputFormat:   lo.ToPtr(format),
+			AllowedFormats: y.AllowedFormats(),
+		}
+	}
+	timeFormat, err := y.TimeFormat.ToTimeFormat()
+	if err != nil {
+		return nil, err
+	}
 	switch format {
 	case "json":
-		return NewJSONPrinter(lo.FromPtrOr(y.JSONIndent, false)), nil
+		return &JSONPrinter{Indent: lo.FromPtrOr(y.JSONIndent, false), TimeFormat: timeFormat}, nil
 	case "yaml":
-		return NewYAMLPrinter(), nil
+		return &YamlPrinter{TimeFormat: timeFormat}, nil
+	case "jsonl", "ndjson":
+		return &JSONLinesPrinter{TimeFormat: timeFormat}, nil
+	case "yaml-stream":
+		return &YamlPrinter{SplitDocuments: true, TimeFormat: timeFormat}, nil
 	default:
 		return nil, NoCompatiblePrinterError{
 			OutputFormat:   lo.ToPtr(format),
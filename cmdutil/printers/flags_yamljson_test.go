@@ -62,8 +62,8 @@ var _ = Describe("YamlJSONPrinterFlags", Label("unit"), func() {
 	})
 
 	Describe("AllowedFormats", func() {
-		It("returns json and yaml as allowed formats", func() {
-			Expect(y.AllowedFormats()).To(Equal([]string{"json", "yaml"}))
+		It("returns json, yaml and json lines as allowed formats", func() {
+			Expect(y.AllowedFormats()).To(Equal([]string{"json", "yaml", "jsonl", "ndjson", "yaml-stream"}))
 		})
 	})
 
@@ -84,12 +84,30 @@ var _ = Describe("YamlJSONPrinterFlags", Label("unit"), func() {
 			})
 		})
 
-		Context("when format is not json or yaml", func() {
+		Context("when format is yaml-stream", func() {
+			It("returns a YAMLPrinter that splits documents", func() {
+				printer, err := y.ToPrinter("yaml-stream")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(printer).To(Equal(&printers.YamlPrinter{SplitDocuments: true}))
+			})
+		})
+
+		DescribeTable("when format is json lines",
+			func(format string) {
+				printer, err := y.ToPrinter(format)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(printer).To(BeAssignableToTypeOf(&printers.JSONLinesPrinter{}))
+			},
+			Entry("jsonl", "jsonl"),
+			Entry("ndjson", "ndjson"),
+		)
+
+		Context("when format is not supported", func() {
 			It("returns an error", func() {
 				_, err := y.ToPrinter("xml")
 				Expect(err).To(MatchError(printers.NoCompatiblePrinterError{
 					OutputFormat:   lo.ToPtr("xml"),
-					AllowedFormats: []string{"json", "yaml"},
+					AllowedFormats: []string{"json", "yaml", "jsonl", "ndjson", "yaml-stream"},
 				}))
 			})
 		})
@@ -0,0 +1,254 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ValueFormatter formats a cell value for the "format" header tag option (see GenerateTableData).
+// The argument is the text after the formatter name and a colon, like "2006-01-02" for
+// `header:"CREATED,format=time:2006-01-02"`, or empty. It returns false if it can't format the
+// value, in which case the value is printed as if it had no format.
+type ValueFormatter func(value any, argument string) (string, bool)
+
+var (
+	// valueFormatters holds the ValueFormatter of every name, see RegisterValueFormatter.
+	valueFormatters = struct {
+		sync.RWMutex
+		formatters map[string]ValueFormatter
+	}{formatters: map[string]ValueFormatter{
+		"age":      formatAge,
+		"bool":     formatBool,
+		"bytes":    formatBytes,
+		"duration": formatDuration,
+		"percent":  formatPercent,
+		"time":     formatTime,
+	}}
+	// nowFunc returns the current time for the "age" formatter. It is a variable so that tests can
+	// print stable ages.
+	nowFunc = time.Now
+)
+
+// RegisterValueFormatter registers a ValueFormatter under a name for the "format" header tag
+// option, replacing any formatter already registered under that name, including the built-in
+// formatters:
+//
+//   - age: the time elapsed since a time.Time, like "5m" or "3d4h".
+//   - bool: a bool as the labels given as argument, like "bool:yes/no" (the default).
+//   - bytes: an integer or float number of bytes in IEC units, like "1.5 KiB".
+//   - duration: a time.Duration in the same short format as age.
+//   - percent: a fraction as a percentage, like "42%". The argument is the number of decimals.
+//   - time: a time.Time in the layout given as argument, or time.RFC3339 by default.
+//
+// It is safe for concurrent use.
+func RegisterValueFormatter(name string, formatter ValueFormatter) {
+	valueFormatters.Lock()
+	valueFormatters.formatters[name] = formatter
+	valueFormatters.Unlock()
+
+	// compiled plans hold the formatter that was registered when they were compiled
+	tablePlanCache.Lock()
+	defer tablePlanCache.Unlock()
+	clear(tablePlanCache.plans)
+}
+
+// ValueFormat returns a function that formats values with the ValueFormatter of a "format" header
+// tag option spec, like "bytes" or "time:2006-01-02", for use with [Column.Format]. Values the
+// formatter can't format, or all values if no formatter is registered under the name, are printed
+// like GenerateTableData prints fields.
+func ValueFormat(spec string) func(value any) string {
+	format := withValueFormatter(spec, resolveCellValue)
+	return func(value any) string {
+		if v, ok := indirectValue(reflect.ValueOf(value)); ok && v.IsValid() {
+			return format(v)
+		}
+		return ""
+	}
+}
+
+// withValueFormatter returns a cellFormatter that formats values with the ValueFormatter of a
+// format spec, and with format if the spec is empty, the formatter is not registered, or it can't
+// format a value.
+func withValueFormatter(spec string, format cellFormatter) cellFormatter {
+	if spec == "" {
+		return format
+	}
+	name, argument, _ := strings.Cut(spec, ":")
+	valueFormatters.RLock()
+	formatter, ok := valueFormatters.formatters[name]
+	valueFormatters.RUnlock()
+	if !ok {
+		return format
+	}
+	return func(v reflect.Value) string {
+		if v.CanInterface() {
+			if s, ok := formatter(v.Interface(), argument); ok {
+				return s
+			}
+		}
+		return format(v)
+	}
+}
+
+func formatAge(value any, _ string) (string, bool) {
+	t, ok := value.(time.Time)
+	if !ok {
+		return "", false
+	}
+	if t.IsZero() {
+		return "<unknown>", true
+	}
+	return humanDuration(nowFunc().Sub(t)), true
+}
+
+func formatDuration(value any, _ string) (string, bool) {
+	d, ok := value.(time.Duration)
+	if !ok {
+		return "", false
+	}
+	return humanDuration(d), true
+}
+
+func formatTime(value any, layout string) (string, bool) {
+	t, ok := value.(time.Time)
+	if !ok {
+		return "", false
+	}
+	if t.IsZero() {
+		return "", true
+	}
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	return t.Format(layout), true
+}
+
+func formatBool(value any, labels string) (string, bool) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Bool {
+		return "", false
+	}
+	yes, no, ok := strings.Cut(labels, "/")
+	if !ok {
+		yes, no = "yes", "no"
+	}
+	if v.Bool() {
+		return yes, true
+	}
+	return no, true
+}
+
+func formatBytes(value any, _ string) (string, bool) {
+	size, ok := toFloat(value)
+	if !ok {
+		return "", false
+	}
+	const unit = 1024
+	if math.Abs(size) < unit {
+		return strconv.FormatFloat(size, 'f', -1, 64) + " B", true
+	}
+	exp := 0
+	for math.Abs(size) >= unit && exp < len("KMGTPE") {
+		size /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", size, "KMGTPE"[exp-1]), true
+}
+
+func formatPercent(value any, decimals string) (string, bool) {
+	fraction, ok := toFloat(value)
+	if !ok {
+		return "", false
+	}
+	precision, err := strconv.Atoi(decimals)
+	if err != nil || precision < 0 {
+		precision = 0
+	}
+	return strconv.FormatFloat(fraction*100, 'f', precision, 64) + "%", true
+}
+
+// toFloat converts any integer or float value to a float64.
+func toFloat(value any) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch {
+	case v.CanInt():
+		return float64(v.Int()), true
+	case v.CanUint():
+		return float64(v.Uint()), true
+	case v.CanFloat():
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// humanDuration returns a short, human readable duration with at most two units, like kubectl
+// prints the age of resources: "45s", "5m30s", "3h", "2d4h" or "3y".
+func humanDuration(d time.Duration) string {
+	if d < 0 {
+		return "0s"
+	}
+	seconds := int64(d.Round(time.Second) / time.Second)
+	minutes := seconds / 60
+	hours := minutes / 60
+	days := hours / 24
+	switch {
+	case seconds < 60*2:
+		return fmt.Sprintf("%ds", seconds)
+	case minutes < 10:
+		if s := seconds % 60; s != 0 {
+			return fmt.Sprintf("%dm%ds", minutes, s)
+		}
+		return fmt.Sprintf("%dm", minutes)
+	case minutes < 60*3:
+		return fmt.Sprintf("%dm", minutes)
+	case hours < 8:
+		if m := minutes % 60; m != 0 {
+			return fmt.Sprintf("%dh%dm", hours, m)
+		}
+		return fmt.Sprintf("%dh", hours)
+	case hours < 48:
+		return fmt.Sprintf("%dh", hours)
+	case hours < 24*8:
+		if h := hours % 24; h != 0 {
+			return fmt.Sprintf("%dd%dh", days, h)
+		}
+		return fmt.Sprintf("%dd", days)
+	case days < 365*2:
+		return fmt.Sprintf("%dd", days)
+	case days < 365*8:
+		if dd := days % 365; dd != 0 {
+			return fmt.Sprintf("%dy%dd", days/365, dd)
+		}
+		return fmt.Sprintf("%dy", days/365)
+	default:
+		return fmt.Sprintf("%dy", days/365)
+	}
+}
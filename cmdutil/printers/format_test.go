@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Value formats", Label("unit"), func() {
+	type volume struct {
+		Name     string
+		Size     int64         `header:"SIZE,format=bytes"`
+		Used     float64       `header:"USED,format=percent"`
+		Created  time.Time     `header:"AGE,format=age"`
+		Updated  *time.Time    `header:"UPDATED,format=time:2006-01-02"`
+		Timeout  time.Duration `header:"TIMEOUT,format=duration"`
+		Attached bool          `header:"ATTACHED,format=bool"`
+		Shared   bool          `header:"SHARED,format=bool:Y/N"`
+	}
+
+	It("should format cells with the formatter of their column", func() {
+		updated := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+		headers, rows, err := printers.GenerateTableData([]volume{{
+			Name:     "data",
+			Size:     3 * 1024 * 1024 / 2,
+			Used:     0.425,
+			Created:  time.Now().Add(-3 * time.Hour),
+			Updated:  &updated,
+			Timeout:  90 * time.Second,
+			Attached: true,
+		}, {
+			Name: "empty",
+		}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(headers).To(Equal([]string{
+			"NAME", "SIZE", "USED", "AGE", "UPDATED", "TIMEOUT", "ATTACHED", "SHARED",
+		}))
+		Expect(rows).To(Equal([][]string{
+			{"data", "1.5 MiB", "42%", "3h", "2024-05-06", "90s", "yes", "N"},
+			{"empty", "0 B", "0%", "<unknown>", "", "0s", "no", "N"},
+		}))
+	})
+
+	DescribeTable("the built-in formatters",
+		func(spec string, value any, expected string) {
+			Expect(printers.ValueFormat(spec)(value)).To(Equal(expected))
+		},
+		Entry("bytes", "bytes", 512, "512 B"),
+		Entry("kibibytes", "bytes", uint(2048), "2.0 KiB"),
+		Entry("gibibytes", "bytes", 5.5*1024*1024*1024, "5.5 GiB"),
+		Entry("percent with decimals", "percent:1", 0.4256, "42.6%"),
+		Entry("a duration in minutes and seconds", "duration", 5*time.Minute+30*time.Second, "5m30s"),
+		Entry("a duration in hours and minutes", "duration", 3*time.Hour+15*time.Minute, "3h15m"),
+		Entry("a duration in hours", "duration", 30*time.Hour, "30h"),
+		Entry("a duration in days and hours", "duration", 50*time.Hour, "2d2h"),
+		Entry("a duration in days", "duration", 100*24*time.Hour, "100d"),
+		Entry("a duration in years and days", "duration", 800*24*time.Hour, "2y70d"),
+		Entry("a negative duration", "duration", -time.Second, "0s"),
+		Entry("a time in RFC 3339", "time", time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC), "2024-05-06T07:08:09Z"),
+		Entry("a bool with labels", "bool:on/off", false, "off"),
+		Entry("a value the formatter can't format", "bytes", "large", "large"),
+		Entry("an unknown formatter", "unknown", 42, "42"),
+		Entry("a nil value", "bytes", nil, ""),
+	)
+
+	Context("when formatting ages", func() {
+		now := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+		BeforeEach(func() {
+			DeferCleanup(printers.SetNow(now))
+		})
+
+		DescribeTable("the age formatter",
+			func(created time.Time, expected string) {
+				Expect(printers.ValueFormat("age")(created)).To(Equal(expected))
+			},
+			Entry("in seconds", now.Add(-45*time.Second), "45s"),
+			Entry("in minutes", now.Add(-5*time.Minute), "5m"),
+			Entry("in hours", now.Add(-26*time.Hour), "26h"),
+			Entry("in days and hours", now.Add(-3*24*time.Hour-time.Hour), "3d1h"),
+			Entry("in the future", now.Add(time.Minute), "0s"),
+			Entry("of a zero time", time.Time{}, "<unknown>"),
+		)
+	})
+
+	It("should format the fields of describe output", func() {
+		printer := printers.NewDescribePrinter(printers.PrintOptions{})
+		buffer := new(bytes.Buffer)
+		Expect(printer.PrintObj(volume{Name: "data", Size: 2048}, buffer)).To(Succeed())
+		Expect(buffer.String()).To(ContainSubstring("SIZE:     2.0 KiB\n"))
+	})
+
+	It("should use formatters registered after a type was printed", func() {
+		type tagged struct {
+			Name string `header:"NAME,format=upper"`
+		}
+		_, rows, err := printers.GenerateTableData(tagged{Name: "web"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rows).To(Equal([][]string{{"web"}}))
+
+		printers.RegisterValueFormatter("upper", func(value any, _ string) (string, bool) {
+			s, ok := value.(string)
+			return strings.ToUpper(s), ok
+		})
+		_, rows, err = printers.GenerateTableData(tagged{Name: "web"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rows).To(Equal([][]string{{"WEB"}}))
+	})
+})
@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"html/template"
+	"io"
+	"reflect"
+)
+
+var _ ObjectPrinter = (*HTMLPrinter)(nil)
+
+// DefaultHTMLStyle is the CSS embedded in standalone pages printed by HTMLPrinter.
+const DefaultHTMLStyle = `body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background-color: #f3f3f3; }
+td > table { width: 100%; }`
+
+// HTMLPrinter prints objects as HTML tables.
+//
+// Columns are resolved from struct fields the same way as GenerateTableData, except that fields
+// holding structs, slices or arrays are printed as nested tables instead of being left out or
+// printed as text. Values that implement one of the interfaces supported by GenerateTableData
+// (like fmt.Stringer) are always printed as text. NoHeaders only applies to the top-level table.
+type HTMLPrinter struct {
+	PrintOptions
+	// Standalone configures the printer to print a complete HTML page, with Title and Style
+	// embedded in its head, rather than only the table.
+	Standalone bool
+	// Title is the title of standalone pages.
+	Title string
+	// Style is the CSS embedded in standalone pages.
+	Style string
+}
+
+// htmlTable is an HTML table resolved from an object.
+type htmlTable struct {
+	Headers []string
+	Rows    [][]htmlCell
+}
+
+// htmlCell is a cell of an htmlTable, which holds either text or a nested table.
+type htmlCell struct {
+	Text  string
+	Table *htmlTable
+}
+
+var htmlTemplate = template.Must(template.New("page").Parse(`
+{{- define "table" -}}
+<table>
+{{- if .Headers}}
+<thead><tr>{{range .Headers}}<th>{{.}}</th>{{end}}</tr></thead>
+{{- end}}
+<tbody>
+{{- range .Rows}}
+<tr>{{range .}}<td>{{if .Table}}{{template "table" .Table}}{{else}}{{.Text}}{{end}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+{{- end -}}
+{{- if .Standalone -}}
+<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+{{.Style}}
+</style>
+</head>
+<body>
+{{template "table" .Table}}
+</body>
+</html>
+{{else -}}
+{{template "table" .Table}}
+{{end -}}
+`))
+
+// PrintObj implements ObjectPrinter.
+func (p *HTMLPrinter) PrintObj(obj any, w io.Writer) error {
+	t := &htmlTable{}
+	if v, ok := indirectValue(reflect.ValueOf(obj)); ok && v.IsValid() {
+		t = p.table(v)
+	}
+	if p.NoHeaders {
+		t.Headers = nil
+	}
+	return htmlTemplate.Execute(w, struct {
+		Standalone bool
+		Title      string
+		Style      template.CSS
+		Table      *htmlTable
+	}{
+		Standalone: p.Standalone,
+		Title:      p.Title,
+		Style:      template.CSS(p.Style), //nolint:gosec // the style is provided by the program
+		Table:      t,
+	})
+}
+
+// table resolves the table of a non-nil value. Structs are printed as a single row, slices and
+// arrays as one row per element, and anything else as a single cell.
+func (p *HTMLPrinter) table(v reflect.Value) *htmlTable {
+	if !isNestedValue(v) {
+		return &htmlTable{Rows: [][]htmlCell{{p.cell(v)}}}
+	}
+	if v.Kind() == reflect.Struct {
+		columns := nestedColumnExtract(v.Type(), p.PrintOptions)
+		return &htmlTable{Headers: htmlHeaders(columns), Rows: [][]htmlCell{p.row(v, columns)}}
+	}
+
+	t := &htmlTable{}
+	var columns []tableColumn
+	elemType := indirectType(v.Type().Elem())
+	isStructs := elemType.Kind() == reflect.Struct && !hasStringerInterface(elemType)
+	if isStructs {
+		columns = nestedColumnExtract(elemType, p.PrintOptions)
+		t.Headers = htmlHeaders(columns)
+	}
+	for i := 0; i < v.Len(); i++ {
+		ev, ok := indirectValue(v.Index(i))
+		switch {
+		case !ok:
+			continue
+		case isStructs:
+			t.Rows = append(t.Rows, p.row(ev, columns))
+		default:
+			t.Rows = append(t.Rows, []htmlCell{p.cell(ev)})
+		}
+	}
+	return t
+}
+
+func (p *HTMLPrinter) row(v reflect.Value, columns []tableColumn) []htmlCell {
+	row := make([]htmlCell, 0, len(columns))
+	for _, c := range columns {
+		fv, ok := fieldByIndex(v, c.index)
+		switch {
+		case !ok:
+			row = append(row, htmlCell{})
+		case c.format != nil:
+			row = append(row, htmlCell{Text: c.format(fv)})
+		default:
+			row = append(row, p.cell(fv))
+		}
+	}
+	return row
+}
+
+// cell resolves the cell of a non-nil value, which is a nested table for structs, slices and
+// arrays, and text for anything else.
+func (p *HTMLPrinter) cell(v reflect.Value) htmlCell {
+	if !isNestedValue(v) {
+		return htmlCell{Text: resolveCellValue(v)}
+	}
+	if v.Kind() != reflect.Struct && v.Len() == 0 {
+		return htmlCell{}
+	}
+	return htmlCell{Table: p.table(v)}
+}
+
+func htmlHeaders(columns []tableColumn) []string {
+	headers := make([]string, 0, len(columns))
+	for _, c := range columns {
+		headers = append(headers, c.header)
+	}
+	return headers
+}
+
+func NewHTMLPrinter(options PrintOptions) ObjectPrinter {
+	return &HTMLPrinter{
+		PrintOptions: options,
+		Style:        DefaultHTMLStyle,
+	}
+}
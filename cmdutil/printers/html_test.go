@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HTMLPrinter", Label("unit"), func() {
+	type Port struct {
+		Name string `json:"name"`
+		Port int    `json:"port"`
+	}
+	type Owner struct {
+		Team string
+	}
+	type Service struct {
+		Name    string    `json:"name"`
+		Ports   []Port    `json:"ports"`
+		Tags    []string  `json:"tags"`
+		Owner   Owner     `json:"owner"`
+		Created time.Time `json:"created"`
+		Node    string    `header:"NODE,wide"`
+	}
+
+	var (
+		printer printers.ObjectPrinter
+		buffer  *bytes.Buffer
+		service Service
+	)
+
+	BeforeEach(func() {
+		printer = printers.NewHTMLPrinter(printers.PrintOptions{})
+		buffer = new(bytes.Buffer)
+		service = Service{
+			Name:    "web<1>",
+			Ports:   []Port{{"http", 80}, {"https", 443}},
+			Tags:    []string{"a"},
+			Owner:   Owner{Team: "infra"},
+			Created: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+	})
+
+	It("should print slice and struct fields as nested tables", func() {
+		Expect(printer.PrintObj([]Service{service}, buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal(`<table>
+<thead><tr><th>NAME</th><th>PORTS</th><th>TAGS</th><th>OWNER</th><th>CREATED</th></tr></thead>
+<tbody>
+<tr><td>web&lt;1&gt;</td><td><table>
+<thead><tr><th>NAME</th><th>PORT</th></tr></thead>
+<tbody>
+<tr><td>http</td><td>80</td></tr>
+<tr><td>https</td><td>443</td></tr>
+</tbody>
+</table></td><td><table>
+<tbody>
+<tr><td>a</td></tr>
+</tbody>
+</table></td><td><table>
+<thead><tr><th>TEAM</th></tr></thead>
+<tbody>
+<tr><td>infra</td></tr>
+</tbody>
+</table></td><td>2024-01-02 03:04:05 &#43;0000 UTC</td></tr>
+</tbody>
+</table>
+`))
+	})
+
+	It("should include wide columns in wide output", func() {
+		printer = printers.NewHTMLPrinter(printers.PrintOptions{Wide: true})
+		service.Node = "node-1"
+		Expect(printer.PrintObj(service, buffer)).To(Succeed())
+		Expect(buffer.String()).To(ContainSubstring("<th>NODE</th>"))
+		Expect(buffer.String()).To(ContainSubstring("<td>node-1</td>"))
+	})
+
+	It("should not print the top-level headers without headers", func() {
+		printer = printers.NewHTMLPrinter(printers.PrintOptions{NoHeaders: true})
+		Expect(printer.PrintObj(service, buffer)).To(Succeed())
+		Expect(buffer.String()).NotTo(ContainSubstring("<th>CREATED</th>"))
+		Expect(buffer.String()).To(ContainSubstring("<th>TEAM</th>"))
+	})
+
+	It("should print a standalone page with embedded CSS", func() {
+		printer = &printers.HTMLPrinter{
+			Standalone: true,
+			Title:      "Services",
+			Style:      printers.DefaultHTMLStyle,
+		}
+		Expect(printer.PrintObj(service, buffer)).To(Succeed())
+		Expect(buffer.String()).To(HavePrefix("<!DOCTYPE html>\n<html>\n<head>\n"))
+		Expect(buffer.String()).To(ContainSubstring("<title>Services</title>"))
+		Expect(buffer.String()).To(ContainSubstring("border-collapse: collapse;"))
+		Expect(buffer.String()).To(ContainSubstring("<body>\n<table>"))
+		Expect(buffer.String()).To(HaveSuffix("</table>\n</body>\n</html>\n"))
+	})
+
+	It("should print an empty table for a nil object", func() {
+		Expect(printer.PrintObj(nil, buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal("<table>\n<tbody>\n</tbody>\n</table>\n"))
+	})
+})
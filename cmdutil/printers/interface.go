@@ -45,6 +45,14 @@ type ObjectPrinter interface {
 type PrintOptions struct {
 	// NoHeaders configures table-based printers to skip printing headers to the output.
 	NoHeaders bool
-	// Wide configures table-based printers to include additional columns in their output.
+	// Wide configures table-based printers to include additional columns in their output, such as
+	// fields with the "wide" header tag option.
 	Wide bool
+	// ShowKind configures table-based printers to prepend a KIND column to structs and collections
+	// of structs, which holds the name of the type of every row. This is most useful for
+	// collections that hold different types, like []any.
+	ShowKind bool
+	// TimeFormat configures table-based printers to print time.Time fields in a time zone and
+	// layout. Fields with a "format" header tag option are only converted to the time zone.
+	TimeFormat TimeFormat
 }
@@ -27,10 +27,12 @@ import (
 	"io"
 )
 
-var _ ObjectPrinter = (*JSONPrinter)(nil)
+var _ StreamingPrinter = (*JSONPrinter)(nil)
 
 type JSONPrinter struct {
 	Indent bool
+	// TimeFormat configures the time zone and layout of time.Time values.
+	TimeFormat TimeFormat
 }
 
 // PrintObj implements ObjectPrinter.
@@ -39,7 +41,33 @@ func (j *JSONPrinter) PrintObj(obj any, w io.Writer) error {
 	if j.Indent {
 		enc.SetIndent("", "  ")
 	}
-	return enc.Encode(obj)
+	return j.TimeFormat.encodeJSON(enc, obj)
+}
+
+// NewStream implements StreamingPrinter. Objects are written as newline-delimited JSON, one
+// compact document per line, regardless of Indent.
+func (j *JSONPrinter) NewStream(w io.Writer) ObjectStream {
+	return &jsonStream{enc: json.NewEncoder(w), timeFormat: j.TimeFormat}
+}
+
+type jsonStream struct {
+	enc        *json.Encoder
+	timeFormat TimeFormat
+}
+
+// Write implements ObjectStream.
+func (s *jsonStream) Write(obj any) error {
+	return s.timeFormat.encodeJSON(s.enc, obj)
+}
+
+// Flush implements ObjectStream.
+func (s *jsonStream) Flush() error {
+	return nil
+}
+
+// Close implements ObjectStream.
+func (s *jsonStream) Close() error {
+	return nil
 }
 
 func NewJSONPrinter(indent bool) ObjectPrinter {
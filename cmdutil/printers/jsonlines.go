@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"encoding/json"
+	"io"
+)
+
+var _ StreamingPrinter = (*JSONLinesPrinter)(nil)
+
+// JSONLinesPrinter prints objects as newline-delimited JSON (JSON Lines), with one compact JSON
+// document per line.
+//
+// Slices, arrays, iterator functions and channels are printed with one line per element, while
+// any other object is printed as a single line. Types that implement [json.Marshaler] are always
+// printed as a single line.
+type JSONLinesPrinter struct {
+	// TimeFormat configures the time zone and layout of time.Time values.
+	TimeFormat TimeFormat
+}
+
+// PrintObj implements ObjectPrinter.
+func (p *JSONLinesPrinter) PrintObj(obj any, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	encode := func(obj any) error { return p.TimeFormat.encodeJSON(enc, obj) }
+	if _, ok := obj.(json.Marshaler); ok {
+		return encode(obj)
+	}
+	return rangeElements(obj, encode)
+}
+
+// NewStream implements StreamingPrinter. Every object written to the stream is printed as if it
+// were passed to PrintObj.
+func (p *JSONLinesPrinter) NewStream(w io.Writer) ObjectStream {
+	return &jsonLinesStream{printer: p, w: w}
+}
+
+type jsonLinesStream struct {
+	printer *JSONLinesPrinter
+	w       io.Writer
+}
+
+// Write implements ObjectStream.
+func (s *jsonLinesStream) Write(obj any) error {
+	return s.printer.PrintObj(obj, s.w)
+}
+
+// Flush implements ObjectStream.
+func (s *jsonLinesStream) Flush() error {
+	return nil
+}
+
+// Close implements ObjectStream.
+func (s *jsonLinesStream) Close() error {
+	return nil
+}
+
+func NewJSONLinesPrinter() ObjectPrinter {
+	return &JSONLinesPrinter{}
+}
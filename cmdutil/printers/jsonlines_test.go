@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type jsonLinesList []string
+
+func (l jsonLinesList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string][]string{"items": l})
+}
+
+var _ = Describe("JSONLinesPrinter", Label("unit"), func() {
+	type item struct {
+		Name string `json:"name"`
+	}
+
+	var (
+		printer printers.ObjectPrinter
+		buffer  *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		printer = printers.NewJSONLinesPrinter()
+		buffer = new(bytes.Buffer)
+	})
+
+	DescribeTable("PrintObj",
+		func(obj any, expected string) {
+			Expect(printer.PrintObj(obj, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal(expected))
+		},
+		Entry("a single object", item{"a"}, "{\"name\":\"a\"}\n"),
+		Entry("a slice", []item{{"a"}, {"b"}}, "{\"name\":\"a\"}\n{\"name\":\"b\"}\n"),
+		Entry("a pointer to a slice", &[]item{{"a"}, {"b"}}, "{\"name\":\"a\"}\n{\"name\":\"b\"}\n"),
+		Entry("an array", [2]int{1, 2}, "1\n2\n"),
+		Entry("an empty slice", []item{}, ""),
+		Entry("a byte slice", []byte("hi"), "\"aGk=\"\n"),
+		Entry("a slice with a custom marshaler", jsonLinesList{"a", "b"}, "{\"items\":[\"a\",\"b\"]}\n"),
+		Entry("an iterator function", func(yield func(item) bool) {
+			_ = yield(item{"a"}) && yield(item{"b"})
+		}, "{\"name\":\"a\"}\n{\"name\":\"b\"}\n"),
+		Entry("a nil object", nil, "null\n"),
+	)
+
+	It("should print one line per element received from a channel", func() {
+		ch := make(chan item, 2)
+		ch <- item{"a"}
+		ch <- item{"b"}
+		close(ch)
+		Expect(printer.PrintObj(ch, buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal("{\"name\":\"a\"}\n{\"name\":\"b\"}\n"))
+	})
+
+	It("should return an error when an element cannot be marshalled", func() {
+		Expect(printer.PrintObj([]any{1, func() {}}, buffer)).NotTo(Succeed())
+		Expect(buffer.String()).To(Equal("1\n"))
+	})
+})
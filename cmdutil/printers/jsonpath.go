@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+var _ ObjectPrinter = (*JSONPathPrinter)(nil)
+
+// JSONPathPrinter is an implementation of ObjectPrinter which formats data with a jsonpath
+// template, using the same syntax as kubectl (e.g. "{.items[*].name}").
+//
+// Objects are converted to their JSON representation before the template is executed, so field
+// names in the template match the output of JSONPrinter.
+type JSONPathPrinter struct {
+	*jsonpath.JSONPath
+	rawTemplate string
+}
+
+// PrintObj implements ObjectPrinter.
+func (j *JSONPathPrinter) PrintObj(obj any, w io.Writer) (err error) {
+	defer err2.Handle(&err, func(err error) error {
+		return fmt.Errorf("error executing jsonpath %q: %w", j.rawTemplate, err)
+	})
+	try.To(j.JSONPath.Execute(w, try.To1(toJSONValue(obj))))
+	return nil
+}
+
+// NewJSONPathPrinter parses the given jsonpath template and returns a JSONPathPrinter for it.
+func NewJSONPathPrinter(tmpl string) (_ *JSONPathPrinter, err error) {
+	defer err2.Handle(&err, func(err error) error {
+		return fmt.Errorf("error parsing jsonpath %q: %w", tmpl, err)
+	})
+	j := jsonpath.New("out")
+	try.To(j.Parse(tmpl))
+	return &JSONPathPrinter{
+		JSONPath:    j,
+		rawTemplate: tmpl,
+	}, nil
+}
+
+// toJSONValue round-trips obj through encoding/json, producing the generic maps, slices and
+// scalars that its JSON representation decodes to.
+//
+// Numbers are decoded as int64 when they are integral and float64 otherwise, so large integers
+// are not rendered in exponent form (e.g. 12345678 rather than 1.2345678e+07).
+func toJSONValue(obj any) (value any, err error) {
+	defer err2.Handle(&err, nil)
+	decoder := json.NewDecoder(bytes.NewReader(try.To1(json.Marshal(obj))))
+	decoder.UseNumber()
+	try.To(decoder.Decode(&value))
+	return fromJSONNumbers(value), nil
+}
+
+// fromJSONNumbers replaces every json.Number within value with an int64, or a float64 when the
+// number is not integral.
+func fromJSONNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for key, elem := range v {
+			v[key] = fromJSONNumbers(elem)
+		}
+	case []any:
+		for i, elem := range v {
+			v[i] = fromJSONNumbers(elem)
+		}
+	}
+	return value
+}
@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSONPathPrinter", Label("unit"), func() {
+	type Item struct {
+		Name  string `json:"name"`
+		Ready bool   `json:"ready"`
+	}
+
+	type List struct {
+		Items []Item `json:"items"`
+	}
+
+	var (
+		list   List
+		buffer *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		list = List{Items: []Item{{Name: "foo", Ready: true}, {Name: "bar", Ready: false}}}
+		buffer = &bytes.Buffer{}
+	})
+
+	Context("when parsing a template", func() {
+		It("should return an error for an invalid template", func() {
+			_, err := printers.NewJSONPathPrinter("{.items[*")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("when printing an object", func() {
+		It("should resolve fields using their json names", func() {
+			printer, err := printers.NewJSONPathPrinter("{.items[*].name}")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(printer.PrintObj(list, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("foo bar"))
+		})
+
+		It("should support range and end", func() {
+			printer, err := printers.NewJSONPathPrinter(
+				`{range .items[*]}{.name}{"\t"}{.ready}{"\n"}{end}`,
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(printer.PrintObj(list, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("foo\ttrue\nbar\tfalse\n"))
+		})
+
+		It("should support filters", func() {
+			printer, err := printers.NewJSONPathPrinter(`{.items[?(@.ready==true)].name}`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(printer.PrintObj(list, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("foo"))
+		})
+
+		It("should support slices at the top level", func() {
+			printer, err := printers.NewJSONPathPrinter("{[1].name}")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(printer.PrintObj(list.Items, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("bar"))
+		})
+
+		It("should return an error for a missing key unless missing keys are allowed", func() {
+			printer, err := printers.NewJSONPathPrinter("{.missing}")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(printer.PrintObj(list, buffer)).NotTo(Succeed())
+
+			buffer.Reset()
+			printer.AllowMissingKeys(true)
+			Expect(printer.PrintObj(list, buffer)).To(Succeed())
+			Expect(buffer.String()).To(BeEmpty())
+		})
+
+		It("should print large integers without an exponent", func() {
+			printer, err := printers.NewJSONPathPrinter("{.size} {.ratio}")
+			Expect(err).NotTo(HaveOccurred())
+			obj := struct {
+				Size  int64   `json:"size"`
+				Ratio float64 `json:"ratio"`
+			}{Size: 12345678, Ratio: 0.5}
+			Expect(printer.PrintObj(obj, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("12345678 0.5"))
+		})
+
+		It("should compare numbers in filters", func() {
+			printer, err := printers.NewJSONPathPrinter(`{.items[?(@.size>100)].name}`)
+			Expect(err).NotTo(HaveOccurred())
+			obj := map[string]any{"items": []map[string]any{
+				{"name": "small", "size": 10},
+				{"name": "large", "size": 12345678},
+			}}
+			Expect(printer.PrintObj(obj, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("large"))
+		})
+
+		It("should return an error when the object cannot be marshalled", func() {
+			printer, err := printers.NewJSONPathPrinter("{.name}")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(printer.PrintObj(func() {}, buffer)).NotTo(Succeed())
+		})
+	})
+})
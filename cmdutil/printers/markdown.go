@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"io"
+	"strings"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+	"github.com/samber/lo"
+)
+
+var _ ObjectPrinter = (*MarkdownPrinter)(nil)
+
+// Alignment is the horizontal alignment of a table column.
+type Alignment int
+
+const (
+	// AlignDefault leaves the alignment of a column up to the renderer.
+	AlignDefault Alignment = iota
+	AlignLeft
+	AlignCenter
+	AlignRight
+)
+
+// MarkdownPrinter prints objects as GitHub-flavored Markdown pipe tables.
+//
+// Pipes in cells are escaped, and newlines are replaced with "<br>" so that every row stays on a
+// single line. Since Markdown tables always have a header row, the header cells are left empty
+// when NoHeaders is set.
+type MarkdownPrinter struct {
+	PrintOptions
+	// Alignments optionally sets the alignment of each column, in order. Columns without an
+	// alignment use AlignDefault.
+	Alignments []Alignment
+	TableReflectorFunc
+}
+
+// PrintObj implements ObjectPrinter.
+func (p *MarkdownPrinter) PrintObj(obj any, w io.Writer) (err error) {
+	defer err2.Handle(&err, nil)
+
+	headers, rows := try.To2(p.TableReflectorFunc(obj))
+	if len(headers) == 0 || len(rows) == 0 {
+		return nil
+	}
+	if p.NoHeaders {
+		headers = make([]string, len(headers))
+	}
+	headers = lo.Map(headers, escapeMarkdownCell)
+	rows = lo.Map(alignRows(rows, len(headers)), func(row []string, _ int) []string {
+		return lo.Map(row, escapeMarkdownCell)
+	})
+
+	widths := lo.Map(headers, func(header string, col int) int {
+		return lo.Max(append(
+			lo.Map(rows, func(row []string, _ int) int { return displayWidth(row[col]) }),
+			displayWidth(header),
+			3, // the shortest delimiter row cell, "---"
+		))
+	})
+
+	var sb strings.Builder
+	writeMarkdownRow(&sb, headers, widths)
+	writeMarkdownRow(&sb, lo.Map(widths, func(width, col int) string {
+		return p.alignment(col).delimiter(width)
+	}), widths)
+	for _, row := range rows {
+		writeMarkdownRow(&sb, row, widths)
+	}
+	_ = try.To1(io.WriteString(w, sb.String()))
+	return nil
+}
+
+func (p *MarkdownPrinter) alignment(col int) Alignment {
+	if col < len(p.Alignments) {
+		return p.Alignments[col]
+	}
+	return AlignDefault
+}
+
+// delimiter returns the cell of a Markdown delimiter row for the given alignment and width.
+func (a Alignment) delimiter(width int) string {
+	switch a {
+	case AlignLeft:
+		return ":" + strings.Repeat("-", width-1)
+	case AlignCenter:
+		return ":" + strings.Repeat("-", width-2) + ":"
+	case AlignRight:
+		return strings.Repeat("-", width-1) + ":"
+	case AlignDefault:
+		fallthrough
+	default:
+		return strings.Repeat("-", width)
+	}
+}
+
+func writeMarkdownRow(sb *strings.Builder, cells []string, widths []int) {
+	sb.WriteString("|")
+	for col, cell := range cells {
+		sb.WriteString(" ")
+		sb.WriteString(cell)
+		sb.WriteString(strings.Repeat(" ", widths[col]-displayWidth(cell)))
+		sb.WriteString(" |")
+	}
+	sb.WriteString("\n")
+}
+
+var markdownCellReplacer = strings.NewReplacer(
+	"|", `\|`,
+	"\r\n", "<br>",
+	"\n", "<br>",
+	"\r", "<br>",
+)
+
+func escapeMarkdownCell(cell string, _ int) string {
+	return markdownCellReplacer.Replace(cell)
+}
+
+func NewMarkdownPrinter(options PrintOptions) ObjectPrinter {
+	printer := &MarkdownPrinter{
+		PrintOptions:       options,
+		TableReflectorFunc: defaultTableReflectorFunc(options),
+	}
+	return printer
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MarkdownPrinter", Label("unit"), func() {
+	type release struct {
+		Name  string
+		Notes string
+		Count int
+	}
+
+	var buffer *bytes.Buffer
+
+	BeforeEach(func() {
+		buffer = new(bytes.Buffer)
+	})
+
+	It("should print a pipe table", func() {
+		printer := printers.NewMarkdownPrinter(printers.PrintOptions{})
+		err := printer.PrintObj([]release{{"v1.0.0", "first", 1}, {"v1.1.0", "second", 22}}, buffer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buffer.String()).To(Equal(`| NAME   | NOTES  | COUNT |
+| ------ | ------ | ----- |
+| v1.0.0 | first  | 1     |
+| v1.1.0 | second | 22    |
+`))
+	})
+
+	It("should escape pipes and newlines in cells", func() {
+		printer := printers.NewMarkdownPrinter(printers.PrintOptions{})
+		err := printer.PrintObj(release{"a|b", "line 1\nline 2", 1}, buffer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buffer.String()).To(Equal(`| NAME | NOTES            | COUNT |
+| ---- | ---------------- | ----- |
+| a\|b | line 1<br>line 2 | 1     |
+`))
+	})
+
+	It("should pad cells in mixed scripts by display width", func() {
+		printer := printers.NewMarkdownPrinter(printers.PrintOptions{})
+		err := printer.PrintObj([]release{{"日本語", "🎉", 1}, {"Jose\u0301", "\x1b[1mbold\x1b[0m", 2}}, buffer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buffer.String()).To(Equal("" +
+			"| NAME   | NOTES | COUNT |\n" +
+			"| ------ | ----- | ----- |\n" +
+			"| 日本語 | 🎉    | 1     |\n" +
+			"| Jose\u0301   | \x1b[1mbold\x1b[0m  | 2     |\n",
+		))
+	})
+
+	It("should print column alignment markers", func() {
+		printer := &printers.MarkdownPrinter{
+			Alignments: []printers.Alignment{
+				printers.AlignLeft,
+				printers.AlignCenter,
+				printers.AlignRight,
+			},
+			TableReflectorFunc: printers.DefaultTableReflectorFunc,
+		}
+		Expect(printer.PrintObj(release{"v1", "n", 1}, buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal(`| NAME | NOTES | COUNT |
+| :--- | :---: | ----: |
+| v1   | n     | 1     |
+`))
+	})
+
+	It("should leave the header cells empty without headers", func() {
+		printer := printers.NewMarkdownPrinter(printers.PrintOptions{NoHeaders: true})
+		Expect(printer.PrintObj(release{"v1", "n", 1}, buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal(`|     |     |     |
+| --- | --- | --- |
+| v1  | n   | 1   |
+`))
+	})
+
+	It("should not print anything for an empty slice", func() {
+		printer := printers.NewMarkdownPrinter(printers.PrintOptions{})
+		Expect(printer.PrintObj([]release{}, buffer)).To(Succeed())
+		Expect(buffer.String()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"io"
+	"strings"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+	"github.com/samber/lo"
+)
+
+var _ ObjectPrinter = (*PlainPrinter)(nil)
+
+const (
+	// DefaultPlainColumnPadding is the number of spaces between the columns printed by PlainPrinter.
+	DefaultPlainColumnPadding = 3
+)
+
+// PlainPrinter prints objects as a plain-text table, like kubectl: columns are left-aligned by
+// display width and separated by spaces, without borders, colors or fixed cell widths, so the
+// output is easy to process with tools like awk and cut. Tabs and newlines in cells are replaced
+// with spaces so that every row stays on a single line.
+type PlainPrinter struct {
+	PrintOptions
+	// Padding is the number of spaces between columns.
+	Padding int
+	TableReflectorFunc
+}
+
+// PrintObj implements ObjectPrinter.
+func (p *PlainPrinter) PrintObj(obj any, w io.Writer) (err error) {
+	defer err2.Handle(&err, nil)
+
+	headers, rows := try.To2(p.TableReflectorFunc(obj))
+	if len(headers) == 0 || len(rows) == 0 {
+		return nil
+	}
+	rows = alignRows(rows, len(headers))
+	if !p.NoHeaders {
+		rows = append([][]string{headers}, rows...)
+	}
+
+	rows = lo.Map(rows, func(row []string, _ int) []string {
+		return lo.Map(row, func(cell string, _ int) string { return plainCellReplacer.Replace(cell) })
+	})
+	widths := columnWidths(rows[0], rows[1:])
+
+	var sb strings.Builder
+	for _, row := range rows {
+		var line strings.Builder
+		for col, cell := range row {
+			line.WriteString(cell)
+			if col < len(row)-1 {
+				line.WriteString(strings.Repeat(" ", widths[col]-displayWidth(cell)+p.Padding))
+			}
+		}
+		// trailing cells may be empty, which would leave the padding of the cells before them
+		sb.WriteString(strings.TrimRight(line.String(), " "))
+		sb.WriteString("\n")
+	}
+	_ = try.To1(io.WriteString(w, sb.String()))
+	return nil
+}
+
+var plainCellReplacer = strings.NewReplacer(
+	"\t", " ",
+	"\r\n", " ",
+	"\n", " ",
+	"\r", " ",
+)
+
+func NewPlainPrinter(options PrintOptions) ObjectPrinter {
+	printer := &PlainPrinter{
+		PrintOptions:       options,
+		Padding:            DefaultPlainColumnPadding,
+		TableReflectorFunc: defaultTableReflectorFunc(options),
+	}
+	return printer
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PlainPrinter", Label("unit"), func() {
+	type pod struct {
+		Name     string
+		Ready    string
+		Restarts int
+		Node     string `header:"NODE,wide"`
+	}
+
+	var (
+		pods   []pod
+		buffer *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		pods = []pod{{"web-1", "1/1", 0, "node-a"}, {"worker-long-name", "0/1", 12, "node-b"}}
+		buffer = new(bytes.Buffer)
+	})
+
+	It("should align columns with spaces", func() {
+		printer := printers.NewPlainPrinter(printers.PrintOptions{})
+		Expect(printer.PrintObj(pods, buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal("" +
+			"NAME               READY   RESTARTS\n" +
+			"web-1              1/1     0\n" +
+			"worker-long-name   0/1     12\n",
+		))
+	})
+
+	It("should include wide columns and skip headers", func() {
+		printer := printers.NewPlainPrinter(printers.PrintOptions{NoHeaders: true, Wide: true})
+		Expect(printer.PrintObj(pods, buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal("" +
+			"web-1              1/1   0    node-a\n" +
+			"worker-long-name   0/1   12   node-b\n",
+		))
+	})
+
+	It("should keep cells with tabs and newlines on a single line", func() {
+		printer := printers.NewPlainPrinter(printers.PrintOptions{})
+		Expect(printer.PrintObj(pod{Name: "a\tb", Ready: "line 1\nline 2"}, buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal("" +
+			"NAME   READY           RESTARTS\n" +
+			"a b    line 1 line 2   0\n",
+		))
+	})
+
+	It("should align cells in mixed scripts or with ANSI escape sequences by display width", func() {
+		printer := printers.NewPlainPrinter(printers.PrintOptions{})
+		Expect(printer.PrintObj([]pod{
+			{Name: "日本語", Ready: "1/1"},
+			{Name: "Jose\u0301", Ready: "1/1"},
+			{Name: "\x1b[31mred\x1b[0m", Ready: "0/1"},
+		}, buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal("" +
+			"NAME     READY   RESTARTS\n" +
+			"日本語   1/1     0\n" +
+			"Jose\u0301     1/1     0\n" +
+			"\x1b[31mred\x1b[0m      0/1     0\n",
+		))
+	})
+
+	It("should not leave trailing whitespace after empty trailing cells", func() {
+		type item struct {
+			Name  string
+			Size  string
+			Notes string
+		}
+		printer := printers.NewPlainPrinter(printers.PrintOptions{})
+		Expect(printer.PrintObj([]item{
+			{Name: "a", Size: "1", Notes: "first"},
+			{Name: "b", Size: "22"},
+			{Name: "c"},
+		}, buffer)).To(Succeed())
+		Expect(strings.Split(buffer.String(), "\n")).To(Equal([]string{
+			"NAME   SIZE   NOTES",
+			"a      1      first",
+			"b      22",
+			"c",
+			"",
+		}))
+	})
+
+	It("should not print anything for an empty slice", func() {
+		printer := printers.NewPlainPrinter(printers.PrintOptions{})
+		Expect(printer.PrintObj([]pod{}, buffer)).To(Succeed())
+		Expect(buffer.String()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+)
+
+// ObjectStream prints objects one at a time as they become available.
+type ObjectStream interface {
+	// Write prints the provided object to the stream. Implementations may buffer objects until
+	// they have enough information to print them, such as the column widths of a table.
+	Write(obj any) error
+	// Flush prints any buffered objects to the underlying [io.Writer].
+	Flush() error
+	// Close flushes the stream and prints anything needed to terminate the output, such as the
+	// bottom border of a table. The stream must not be written to after it has been closed.
+	Close() error
+}
+
+// StreamingPrinter is an [ObjectPrinter] that can also print objects as a stream, without needing
+// the entire dataset up front.
+type StreamingPrinter interface {
+	ObjectPrinter
+	// NewStream returns an [ObjectStream] that prints objects to the provided [io.Writer].
+	NewStream(w io.Writer) ObjectStream
+}
+
+// PrintStream prints every object produced by src to the provided [io.Writer].
+//
+// src may be an iterator function such as iter.Seq[T] (any func(yield func(T) bool)) or a
+// receive-capable channel such as <-chan T. If the printer is a [StreamingPrinter], each object is
+// printed as soon as it is produced. Otherwise, the objects are collected into a slice and printed
+// with a single call to PrintObj once src is exhausted.
+func PrintStream(p ObjectPrinter, src any, w io.Writer) (err error) {
+	defer err2.Handle(&err, nil)
+
+	sp, ok := p.(StreamingPrinter)
+	if !ok {
+		var objs []any
+		try.To(rangeStream(src, func(obj any) error {
+			objs = append(objs, obj)
+			return nil
+		}))
+		return p.PrintObj(objs, w)
+	}
+
+	stream := sp.NewStream(w)
+	defer func() {
+		if closeErr := stream.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	return rangeStream(src, stream.Write)
+}
+
+// rangeStream calls fn for every object produced by src, which must be an iterator function or a
+// channel. Iteration stops at the first error returned by fn.
+func rangeStream(src any, fn func(obj any) error) (err error) {
+	if seq, ok := src.(func(yield func(any) bool)); ok {
+		seq(func(obj any) bool {
+			err = fn(obj)
+			return err == nil
+		})
+		return err
+	}
+
+	v := reflect.ValueOf(src)
+	switch {
+	case isSeqFunc(v):
+		yieldType := v.Type().In(0)
+		yield := reflect.MakeFunc(yieldType, func(args []reflect.Value) []reflect.Value {
+			err = fn(args[0].Interface())
+			return []reflect.Value{reflect.ValueOf(err == nil).Convert(yieldType.Out(0))}
+		})
+		v.Call([]reflect.Value{yield})
+		return err
+	case v.Kind() == reflect.Chan && v.Type().ChanDir()&reflect.RecvDir != 0:
+		for {
+			obj, ok := v.Recv()
+			if !ok {
+				return nil
+			}
+			if err = fn(obj.Interface()); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported stream source %T: expected an iterator function or a channel", src)
+	}
+}
+
+// rangeElements calls fn for every element of obj if obj is a slice, array, iterator function or
+// channel, and for obj itself otherwise. Byte slices are not treated as collections.
+func rangeElements(obj any, fn func(obj any) error) error {
+	if isStreamSource(obj) {
+		return rangeStream(obj, fn)
+	}
+	v, ok := indirectValue(reflect.ValueOf(obj))
+	if !ok || (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) ||
+		v.Type().Elem().Kind() == reflect.Uint8 {
+		return fn(obj)
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := fn(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isStreamSource reports whether src is an iterator function or a channel that rangeStream can
+// iterate over.
+func isStreamSource(src any) bool {
+	if _, ok := src.(func(yield func(any) bool)); ok {
+		return true
+	}
+	v := reflect.ValueOf(src)
+	return isSeqFunc(v) || (v.Kind() == reflect.Chan && v.Type().ChanDir()&reflect.RecvDir != 0)
+}
+
+// isSeqFunc reports whether v is a non-nil function shaped like iter.Seq[T].
+func isSeqFunc(v reflect.Value) bool {
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return false
+	}
+	t := v.Type()
+	if t.NumIn() != 1 || t.NumOut() != 0 || t.IsVariadic() {
+		return false
+	}
+	yield := t.In(0)
+	return yield.Kind() == reflect.Func &&
+		yield.NumIn() == 1 &&
+		yield.NumOut() == 1 &&
+		!yield.IsVariadic() &&
+		yield.Out(0).Kind() == reflect.Bool
+}
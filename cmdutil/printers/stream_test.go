@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type streamItem struct {
+	Name  string
+	Count int
+}
+
+func streamItems(items ...streamItem) func(yield func(streamItem) bool) {
+	return func(yield func(streamItem) bool) {
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+var _ = Describe("PrintStream", Label("unit"), func() {
+	var buffer *bytes.Buffer
+
+	BeforeEach(func() {
+		buffer = new(bytes.Buffer)
+	})
+
+	It("should print CSV rows from an iterator function", func() {
+		printer := printers.NewCSVPrinter(printers.PrintOptions{})
+		src := streamItems(streamItem{"a", 1}, streamItem{"b", 2})
+		Expect(printers.PrintStream(printer, src, buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal("NAME,COUNT\na,1\nb,2\n"))
+	})
+
+	It("should print newline-delimited JSON from a channel", func() {
+		printer := printers.NewJSONPrinter(true)
+		ch := make(chan streamItem, 2)
+		ch <- streamItem{"a", 1}
+		ch <- streamItem{"b", 2}
+		close(ch)
+		Expect(printers.PrintStream(printer, (<-chan streamItem)(ch), buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal("{\"Name\":\"a\",\"Count\":1}\n{\"Name\":\"b\",\"Count\":2}\n"))
+	})
+
+	It("should collect objects for printers that do not support streaming", func() {
+		var printed any
+		printer := printers.ObjectPrinterFunc(func(obj any, _ io.Writer) error {
+			printed = obj
+			return nil
+		})
+		Expect(printers.PrintStream(printer, streamItems(streamItem{"a", 1}), buffer)).To(Succeed())
+		Expect(printed).To(Equal([]any{streamItem{"a", 1}}))
+	})
+
+	It("should stop iterating when the stream returns an error", func() {
+		printer := &printers.CSVPrinter{
+			TableReflectorFunc: func(any) ([]string, [][]string, error) {
+				return nil, nil, errors.New("boom")
+			},
+		}
+		yielded := 0
+		src := func(yield func(int) bool) {
+			for i := 0; i < 3; i++ {
+				yielded++
+				if !yield(i) {
+					return
+				}
+			}
+		}
+		Expect(printers.PrintStream(printer, src, buffer)).To(MatchError("boom"))
+		Expect(yielded).To(Equal(1))
+	})
+
+	It("should return an error for an unsupported source", func() {
+		printer := printers.NewCSVPrinter(printers.PrintOptions{})
+		Expect(printers.PrintStream(printer, []int{1, 2}, buffer)).
+			To(MatchError(ContainSubstring("unsupported stream source []int")))
+	})
+})
+
+var _ = Describe("TablePrinter NewStream", Label("unit"), func() {
+	var (
+		printer *printers.TablePrinter
+		buffer  *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		buffer = new(bytes.Buffer)
+		if p, ok := printers.NewTablePrinter(printers.PrintOptions{}).(*printers.TablePrinter); !ok {
+			Fail("failed to create TablePrinter")
+		} else {
+			printer = p
+		}
+	})
+
+	It("should match PrintObj when every row fits in the window", func() {
+		items := []streamItem{{"alpha", 1}, {"b", 22}, {"c", 333}}
+		expected := new(bytes.Buffer)
+		Expect(printer.PrintObj(items, expected)).To(Succeed())
+
+		stream := printer.NewStream(buffer)
+		for _, item := range items {
+			Expect(stream.Write(item)).To(Succeed())
+		}
+		Expect(buffer.String()).To(BeEmpty())
+		Expect(stream.Close()).To(Succeed())
+		Expect(buffer.String()).To(Equal(expected.String() + "\n"))
+	})
+
+	It("should print rows as they arrive once the window is full", func() {
+		printer.StreamWindow = 1
+		stream := printer.NewStream(buffer)
+
+		Expect(stream.Write(streamItem{"a", 1})).To(Succeed())
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		Expect(lines).To(HaveLen(4))
+		Expect(lines[1]).To(MatchRegexp(`^│\s*NAME\s*│\s*COUNT\s*│$`))
+		Expect(lines[3]).To(MatchRegexp(`^│\s*a\s*│\s*1\s*│$`))
+
+		Expect(stream.Write(streamItem{"b", 2})).To(Succeed())
+		lines = strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		Expect(lines).To(HaveLen(5))
+		Expect(lines[4]).To(MatchRegexp(`^│\s*b\s*│\s*2\s*│$`))
+
+		Expect(stream.Close()).To(Succeed())
+		lines = strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		Expect(lines).To(HaveLen(6))
+		Expect(lines[5]).To(MatchRegexp(`^└─+┴─+┘$`))
+		Expect(lines[5]).To(HaveLen(len(lines[0])))
+	})
+
+	It("should truncate cells to fixed column widths without buffering", func() {
+		printer.StreamColumnWidths = []int{4, 5}
+		stream := printer.NewStream(buffer)
+
+		Expect(stream.Write(streamItem{"abcdefgh", 1})).To(Succeed())
+		Expect(buffer.String()).To(ContainSubstring("abc…"))
+		Expect(buffer.String()).NotTo(ContainSubstring("abcd"))
+		Expect(stream.Close()).To(Succeed())
+	})
+
+	It("should not print anything when nothing was written", func() {
+		stream := printer.NewStream(buffer)
+		Expect(stream.Close()).To(Succeed())
+		Expect(buffer.String()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("CSVPrinter NewStream", Label("unit"), func() {
+	It("should print headers once and flush every row", func() {
+		buffer := new(bytes.Buffer)
+		printer := &printers.CSVPrinter{
+			TableReflectorFunc: printers.NewTableReflectorFunc(printers.PrintOptions{}),
+		}
+		stream := printer.NewStream(buffer)
+		Expect(stream.Write(streamItem{"a", 1})).To(Succeed())
+		Expect(buffer.String()).To(Equal("NAME,COUNT\na,1\n"))
+		Expect(stream.Write([]streamItem{{"b", 2}, {"c", 3}})).To(Succeed())
+		Expect(buffer.String()).To(Equal("NAME,COUNT\na,1\nb,2\nc,3\n"))
+		Expect(stream.Close()).To(Succeed())
+	})
+})
@@ -24,15 +24,18 @@ package printers
 
 import (
 	"io"
+	"slices"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/lainio/err2"
 	"github.com/lainio/err2/try"
 	"github.com/samber/lo"
 )
 
-var _ ObjectPrinter = (*TablePrinter)(nil)
+var _ StreamingPrinter = (*TablePrinter)(nil)
 
 const (
 	blue      = lipgloss.Color("021")
@@ -52,6 +55,9 @@ var (
 				Width(DefaultTableCellWidth).
 				Align(lipgloss.Center).
 				Foreground(lightGray)
+	// DefaultTableStreamWindow is the number of rows a table stream buffers to compute its column
+	// widths before it starts printing.
+	DefaultTableStreamWindow  = 20
 	DefaultTableBorderType    = lipgloss.NormalBorder()
 	DefaultTableBorderStyle   = lipgloss.NewStyle().Foreground(blue)
 	DefaultTableCustomizeFunc = func(t *table.Table) *table.Table {
@@ -65,6 +71,28 @@ type TablePrinter struct {
 	CellStyle          lipgloss.Style
 	CellStyleFunc      func(style lipgloss.Style, row, col int, value string) lipgloss.Style
 	TableCustomizeFunc func(t *table.Table) *table.Table
+	// Expanded configures when records are printed vertically instead of as a table. Streams
+	// always print a table.
+	Expanded ExpandedMode
+	// TerminalWidth overrides the width of the terminal, which is otherwise detected when the
+	// output is a terminal. When the width is known, the widest columns are shrunk until the table
+	// fits in the terminal.
+	TerminalWidth int
+	// MaxColumnWidth is the maximum width of every column, or zero for no maximum.
+	MaxColumnWidth int
+	// ColumnLayoutFunc optionally resolves how the cells of each column are shortened when they
+	// are wider than the column. By default, cells are truncated with an ellipsis.
+	ColumnLayoutFunc ColumnLayoutFunc
+	// CellStyleRules style the cells of the columns they apply to, in addition to the rules of the
+	// "style" header tag option (see ColumnLayout). They are applied before CellStyleFunc.
+	CellStyleRules []CellStyleRule
+	// StreamWindow is the number of rows a stream buffers before it locks its column widths and
+	// starts printing. See NewStream.
+	StreamWindow int
+	// StreamColumnWidths optionally fixes the width of each column in a stream. Columns with a
+	// width of zero are sized from the buffered rows, and a stream with a fixed width for every
+	// column prints rows without buffering them.
+	StreamColumnWidths []int
 	TableReflectorFunc
 }
 
@@ -77,18 +105,111 @@ func (p *TablePrinter) PrintObj(obj any, w io.Writer) (err error) {
 	if len(headers) == 0 || len(rows) == 0 {
 		return nil
 	}
+	rows = alignRows(rows, len(headers))
 
-	colWidths := lo.Reduce(rows, func(columnWidths []int, row []string, _ int) []int {
-		return lo.Map(row, func(cell string, idx int) int {
-			if len(cell) > columnWidths[idx] {
-				return len(cell)
-			}
-			return columnWidths[idx]
-		})
-	}, lo.Map(headers, func(h string, _ int) int {
-		return len(h)
-	}))
+	if p.Expanded == ExpandedOn {
+		return printExpanded(headers, rows, w)
+	}
+	colWidths := columnWidths(headers, rows)
+	if p.Expanded == ExpandedAuto {
+		t := p.newTable(lo.Ternary(p.NoHeaders, nil, headers), rows, colWidths, 0, nil)
+		if width, ok := p.terminalWidth(w); ok && displayWidth(t.Render()) > width {
+			return printExpanded(headers, rows, w)
+		}
+	}
+
+	layouts := p.columnLayouts(obj, len(headers))
+	styles := cellRuleStyles(headers, layouts, p.CellStyleRules, rows)
+	colWidths = p.fitColumnWidths(colWidths, layouts, w)
+	headers = shortenCells(headers, colWidths, layouts)
+	rows = lo.Map(rows, func(row []string, _ int) []string {
+		return shortenCells(row, colWidths, layouts)
+	})
+
+	t := p.newTable(lo.Ternary(p.NoHeaders, nil, headers), rows, colWidths, 0, styles)
+	_ = try.To1(io.WriteString(w, t.Render()))
+	return nil
+}
 
+// columnLayouts returns the layout of every column of the table resolved from obj.
+func (p *TablePrinter) columnLayouts(obj any, columns int) []ColumnLayout {
+	layouts := make([]ColumnLayout, columns)
+	if p.ColumnLayoutFunc != nil {
+		copy(layouts, p.ColumnLayoutFunc(obj))
+	}
+	return layouts
+}
+
+// fitColumnWidths limits every column to its maximum width and to MaxColumnWidth, and then
+// shrinks the widest columns until the table fits in the terminal that w writes to, if its width
+// is known.
+func (p *TablePrinter) fitColumnWidths(colWidths []int, layouts []ColumnLayout, w io.Writer) []int {
+	colWidths = lo.Map(colWidths, func(width int, col int) int {
+		if layouts[col].MaxWidth > 0 {
+			width = min(width, layouts[col].MaxWidth)
+		}
+		if p.MaxColumnWidth > 0 {
+			width = min(width, p.MaxColumnWidth)
+		}
+		return width
+	})
+	termWidth, ok := p.terminalWidth(w)
+	if !ok {
+		return colWidths
+	}
+	// the borders and padding of the table don't depend on the width of its columns
+	ones := lo.Map(colWidths, func(int, int) int { return 1 })
+	probe := p.newTable(nil, [][]string{make([]string, len(ones))}, ones, 0, nil).Render()
+	overhead := displayWidth(probe) - len(ones)
+	return shrinkColumnWidths(colWidths, termWidth-overhead)
+}
+
+// shortenCells truncates every cell of row that is wider than its column with an ellipsis, except
+// in columns that wrap, whose cells are wrapped by the table when it is rendered.
+func shortenCells(row []string, colWidths []int, layouts []ColumnLayout) []string {
+	return lo.Map(row, func(cell string, col int) string {
+		if layouts[col].Wrap {
+			return cell
+		}
+		return truncateCell(cell, colWidths[col])
+	})
+}
+
+// terminalWidth returns TerminalWidth if it is set, or the detected width of the terminal that w
+// writes to.
+func (p *TablePrinter) terminalWidth(w io.Writer) (int, bool) {
+	if p.TerminalWidth > 0 {
+		return p.TerminalWidth, true
+	}
+	return terminalWidth(w)
+}
+
+// NewStream implements StreamingPrinter. Column widths are taken from StreamColumnWidths, or
+// computed from the first StreamWindow rows written to the stream and fit to the terminal like
+// PrintObj. Once the widths are locked, every row is printed as soon as it is written, and cells
+// wider than their column are always truncated.
+func (p *TablePrinter) NewStream(w io.Writer) ObjectStream {
+	return &tableStream{printer: p, w: w}
+}
+
+// newWatchStream implements watchStreamer. Unlike NewStream, columns widen when later rows have
+// wider cells instead of truncating them, so rows printed while watching never lose data. Only
+// StreamColumnWidths, MaxColumnWidth and the "truncate=N" header tag option still limit the width
+// of a column.
+func (p *TablePrinter) newWatchStream(w io.Writer) ObjectStream {
+	return &tableStream{printer: p, w: w, grow: true}
+}
+
+// newTable builds a table for the given rows, with every column rendered at a fixed width. The
+// styles of matching CellStyleRules are applied to the cells of rows, by row and column (see
+// cellRuleStyles), and the rowOffset is added to the row numbers passed to CellStyleFunc.
+func (p *TablePrinter) newTable(
+	headers []string,
+	rows [][]string,
+	colWidths []int,
+	rowOffset int,
+	styles [][]*lipgloss.Style,
+) *table.Table {
 	t := table.New().
 		StyleFunc(func(row, col int) (style lipgloss.Style) {
 			switch {
@@ -99,11 +220,14 @@ func (p *TablePrinter) PrintObj(obj any, w io.Writer) (err error) {
 			}
 
 			style = style.Width(colWidths[col] + style.GetHorizontalPadding())
+			if r := row - 1; r < len(styles) && col < len(styles[r]) && styles[r][col] != nil {
+				style = applyCellStyle(style, *styles[r][col])
+			}
 
 			if p.CellStyleFunc != nil {
 				return p.CellStyleFunc(
 					style,
-					row,
+					row+rowOffset,
 					col,
 					rows[lo.If((row-1) < len(rows), row-1).Else(len(rows)-1)][col],
 				)
@@ -113,22 +237,198 @@ func (p *TablePrinter) PrintObj(obj any, w io.Writer) (err error) {
 	if p.TableCustomizeFunc != nil {
 		t = p.TableCustomizeFunc(t)
 	}
-	if p.NoHeaders {
-		t = t.Headers()
+	return t.Headers(headers...).Data(table.NewStringData(rows...))
+}
+
+// columnWidths returns the display width of the widest cell in every column, including the headers.
+func columnWidths(headers []string, rows [][]string) []int {
+	return lo.Reduce(rows, func(columnWidths []int, row []string, _ int) []int {
+		return lo.Map(row, func(cell string, idx int) int {
+			return max(columnWidths[idx], displayWidth(cell))
+		})
+	}, lo.Map(headers, func(h string, _ int) int {
+		return displayWidth(h)
+	}))
+}
+
+type tableStream struct {
+	printer  *TablePrinter
+	w        io.Writer
+	headers  []string
+	layouts  []ColumnLayout
+	widths   []int
+	buffered [][]string
+	printed  int
+	// grow widens the locked columns to fit the cells of later rows, see newWatchStream.
+	grow bool
+}
+
+// Write implements ObjectStream.
+func (s *tableStream) Write(obj any) (err error) {
+	defer err2.Handle(&err, nil)
+
+	headers, rows := try.To2(s.printer.TableReflectorFunc(obj))
+	if s.headers == nil {
+		if len(headers) == 0 {
+			return nil
+		}
+		s.headers = headers
+		s.layouts = s.printer.columnLayouts(obj, len(headers))
+	}
+	rows = alignRows(rows, len(s.headers))
+
+	if s.widths != nil {
+		return s.print(rows)
+	}
+	s.buffered = append(s.buffered, rows...)
+	fixed := lo.CountBy(s.printer.StreamColumnWidths, func(width int) bool { return width > 0 })
+	if len(s.buffered) >= s.printer.StreamWindow || fixed >= len(s.headers) {
+		return s.Flush()
+	}
+	return nil
+}
+
+// Flush implements ObjectStream. Flushing a stream whose column widths are not locked yet locks
+// them based on the rows buffered so far.
+func (s *tableStream) Flush() error {
+	if len(s.buffered) == 0 {
+		return nil
+	}
+	if s.widths == nil {
+		s.widths = s.printer.fitColumnWidths(columnWidths(s.headers, s.buffered), s.layouts, s.w)
+		for i, width := range s.printer.StreamColumnWidths {
+			if i < len(s.widths) && width > 0 {
+				s.widths[i] = width
+			}
+		}
+	}
+	rows := s.buffered
+	s.buffered = nil
+	return s.print(rows)
+}
+
+// Close implements ObjectStream.
+func (s *tableStream) Close() (err error) {
+	defer err2.Handle(&err, nil)
+
+	try.To(s.Flush())
+	if s.printed == 0 {
+		return nil
+	}
+	if border := s.bottomBorder(); border != "" {
+		_ = try.To1(io.WriteString(s.w, border+"\n"))
+	}
+	return nil
+}
+
+// print renders rows at the locked column widths. The first rows printed by the stream include
+// the headers and the top border, and the bottom border is left for Close.
+func (s *tableStream) print(rows [][]string) (err error) {
+	defer err2.Handle(&err, nil)
+
+	if len(rows) == 0 {
+		return nil
+	}
+	styles := cellRuleStyles(s.headers, s.layouts, s.printer.CellStyleRules, rows)
+	if s.grow {
+		s.growWidths(rows)
+	}
+	rows = lo.Map(rows, func(row []string, _ int) []string {
+		return s.truncate(row)
+	})
+
+	var t *table.Table
+	if s.printed == 0 {
+		headers := lo.Ternary(s.printer.NoHeaders, nil, s.truncate(s.headers))
+		t = s.printer.newTable(headers, rows, s.widths, 0, styles)
 	} else {
-		t = t.Headers(headers...)
+		t = s.printer.newTable(nil, rows, s.widths, s.printed, styles).BorderTop(false)
 	}
-	_ = try.To1(io.WriteString(w, t.Data(table.NewStringData(rows...)).Render()))
+	_ = try.To1(io.WriteString(s.w, t.BorderBottom(false).Render()+"\n"))
+	s.printed += len(rows)
 	return nil
 }
 
+// growWidths widens the locked width of every column to fit the cells of rows, except for columns
+// with a fixed width in StreamColumnWidths, and up to the maximum width of the column.
+func (s *tableStream) growWidths(rows [][]string) {
+	for col := range s.widths {
+		if col < len(s.printer.StreamColumnWidths) && s.printer.StreamColumnWidths[col] > 0 {
+			continue
+		}
+		width := s.widths[col]
+		for _, row := range rows {
+			width = max(width, displayWidth(row[col]))
+		}
+		if s.layouts[col].MaxWidth > 0 {
+			width = min(width, max(s.widths[col], s.layouts[col].MaxWidth))
+		}
+		if s.printer.MaxColumnWidth > 0 {
+			width = min(width, max(s.widths[col], s.printer.MaxColumnWidth))
+		}
+		s.widths[col] = width
+	}
+}
+
+// truncate shortens every cell in row to the locked width of its column.
+func (s *tableStream) truncate(row []string) []string {
+	return lo.Map(row, func(cell string, idx int) string {
+		return truncateCell(cell, s.widths[idx])
+	})
+}
+
+// truncateCell shortens cell to the given display width, replacing the end of the cell with an
+// ellipsis if it does not fit.
+func truncateCell(cell string, width int) string {
+	if displayWidth(cell) <= width {
+		return cell
+	}
+	return ansi.Truncate(cell, width, "…")
+}
+
+// bottomBorder returns the bottom border of the table at the locked column widths, or an empty
+// string if the table is customized to have no bottom border.
+func (s *tableStream) bottomBorder() string {
+	row := [][]string{make([]string, len(s.widths))}
+	withBorder := strings.Split(s.printer.newTable(nil, row, s.widths, 0, nil).BorderTop(false).Render(), "\n")
+	withoutBorder := strings.Split(
+		s.printer.newTable(nil, row, s.widths, 0, nil).BorderTop(false).BorderBottom(false).Render(),
+		"\n",
+	)
+	if len(withBorder) == len(withoutBorder) {
+		return ""
+	}
+	return withBorder[len(withBorder)-1]
+}
+
+// alignRows pads or truncates every row to the given number of columns, so that rows from any
+// TableReflectorFunc line up with their headers.
+func alignRows(rows [][]string, columns int) [][]string {
+	return lo.Map(rows, func(row []string, _ int) []string {
+		if len(row) >= columns {
+			return row[:columns]
+		}
+		return append(slices.Clip(row), make([]string, columns-len(row))...)
+	})
+}
+
 func NewTablePrinter(options PrintOptions) ObjectPrinter {
+	return newTablePrinter(options)
+}
+
+func newTablePrinter(options PrintOptions) *TablePrinter {
 	printer := &TablePrinter{
 		PrintOptions:       options,
 		HeaderStyle:        DefaultTableHeaderStyle,
 		CellStyle:          DefaultTableCellStyle,
 		TableCustomizeFunc: DefaultTableCustomizeFunc,
-		TableReflectorFunc: DefaultTableReflectorFunc,
+		StreamWindow:       DefaultTableStreamWindow,
+		ColumnLayoutFunc:   NewColumnLayoutFunc(options),
+		TableReflectorFunc: defaultTableReflectorFunc(options),
+	}
+	if isCustomTableReflectorFunc() {
+		// The layouts of the struct fields may not line up with the columns of a custom reflector.
+		printer.ColumnLayoutFunc = nil
 	}
 	return printer
 }
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ExpandedMode configures when TablePrinter prints records vertically instead of as a table, like
+// the expanded display of psql.
+type ExpandedMode string
+
+const (
+	// ExpandedOff always prints a table.
+	ExpandedOff ExpandedMode = "off"
+	// ExpandedOn always prints records vertically.
+	ExpandedOn ExpandedMode = "on"
+	// ExpandedAuto prints records vertically only if the table would be wider than the terminal.
+	ExpandedAuto ExpandedMode = "auto"
+)
+
+// ExpandedModes lists every valid ExpandedMode.
+var ExpandedModes = []ExpandedMode{ExpandedAuto, ExpandedOn, ExpandedOff}
+
+// ParseExpandedMode parses an ExpandedMode, like the value of the "--expanded" flag. An empty
+// string is parsed as ExpandedOff.
+func ParseExpandedMode(s string) (ExpandedMode, error) {
+	switch mode := ExpandedMode(s); mode {
+	case "":
+		return ExpandedOff, nil
+	case ExpandedAuto, ExpandedOn, ExpandedOff:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid expanded mode %q: must be one of auto, on, off", s)
+	}
+}
+
+// printExpanded prints every row as a vertical record of "HEADER | value" lines:
+//
+//	-[ RECORD 1 ]------
+//	NAME   | web
+//	STATUS | Running
+//	-[ RECORD 2 ]------
+//	NAME   | worker
+//	STATUS | Pending
+//
+// Headers are always printed, since they label the values of each record.
+func printExpanded(headers []string, rows [][]string, w io.Writer) error {
+	headerWidth := 0
+	for _, h := range headers {
+		headerWidth = max(headerWidth, displayWidth(h))
+	}
+	valueWidth := 0
+	for _, row := range rows {
+		for _, cell := range row {
+			valueWidth = max(valueWidth, displayWidth(cell))
+		}
+	}
+
+	var sb strings.Builder
+	for i, row := range rows {
+		title := fmt.Sprintf("-[ RECORD %d ]", i+1)
+		sb.WriteString(title)
+		sb.WriteString(strings.Repeat("-", max(0, headerWidth+3+valueWidth-displayWidth(title))))
+		sb.WriteString("\n")
+		for col, cell := range row {
+			key := headers[col] + strings.Repeat(" ", headerWidth-displayWidth(headers[col]))
+			for j, line := range strings.Split(cell, "\n") {
+				if j > 0 {
+					key = strings.Repeat(" ", headerWidth)
+				}
+				sb.WriteString(strings.TrimRight(key+" | "+line, " "))
+				sb.WriteString("\n")
+			}
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseExpandedMode", Label("unit"), func() {
+	DescribeTable("parsing expanded modes",
+		func(s string, expected printers.ExpandedMode) {
+			Expect(printers.ParseExpandedMode(s)).To(Equal(expected))
+		},
+		Entry("auto", "auto", printers.ExpandedAuto),
+		Entry("on", "on", printers.ExpandedOn),
+		Entry("off", "off", printers.ExpandedOff),
+		Entry("empty", "", printers.ExpandedOff),
+	)
+
+	It("should return an error for an invalid mode", func() {
+		_, err := printers.ParseExpandedMode("sometimes")
+		Expect(err).To(MatchError(`invalid expanded mode "sometimes": must be one of auto, on, off`))
+	})
+})
+
+var _ = Describe("TablePrinter expanded mode", Label("unit"), func() {
+	type job struct {
+		Name   string
+		Status string
+		Log    string
+	}
+
+	var (
+		printer *printers.TablePrinter
+		buffer  *bytes.Buffer
+		jobs    []job
+	)
+
+	BeforeEach(func() {
+		buffer = new(bytes.Buffer)
+		if p, ok := printers.NewTablePrinter(printers.PrintOptions{}).(*printers.TablePrinter); !ok {
+			Fail("failed to create TablePrinter")
+		} else {
+			printer = p
+		}
+		jobs = []job{{"build", "Running", "step 1\nstep 2"}, {"test", "Pending", ""}}
+	})
+
+	It("should print every record vertically when on", func() {
+		printer.Expanded = printers.ExpandedOn
+		Expect(printer.PrintObj(jobs, buffer)).To(Succeed())
+		Expect(buffer.String()).To(Equal(`-[ RECORD 1 ]---
+NAME   | build
+STATUS | Running
+LOG    | step 1
+       | step 2
+-[ RECORD 2 ]---
+NAME   | test
+STATUS | Pending
+LOG    |
+`))
+	})
+
+	It("should print vertically when the table is wider than the terminal", func() {
+		printer.Expanded = printers.ExpandedAuto
+		printer.TerminalWidth = 20
+		Expect(printer.PrintObj(jobs, buffer)).To(Succeed())
+		Expect(buffer.String()).To(HavePrefix("-[ RECORD 1 ]"))
+	})
+
+	It("should print a table when it fits in the terminal", func() {
+		printer.Expanded = printers.ExpandedAuto
+		printer.TerminalWidth = 200
+		Expect(printer.PrintObj(jobs, buffer)).To(Succeed())
+		Expect(buffer.String()).To(HavePrefix("┌"))
+	})
+
+	It("should ignore $COLUMNS when the output is not a terminal", func() {
+		GinkgoT().Setenv("COLUMNS", "20")
+		printer.Expanded = printers.ExpandedAuto
+		Expect(printer.PrintObj(jobs, buffer)).To(Succeed())
+		Expect(buffer.String()).To(HavePrefix("┌"))
+	})
+
+	It("should print a table when the terminal width is unknown", func() {
+		GinkgoT().Setenv("COLUMNS", "")
+		printer.Expanded = printers.ExpandedAuto
+		Expect(printer.PrintObj(jobs, buffer)).To(Succeed())
+		Expect(buffer.String()).To(HavePrefix("┌"))
+	})
+})
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/samber/lo"
+)
+
+// ColumnLayout configures how the cells of a table column are shortened when they are wider than
+// the column, and how they are styled.
+type ColumnLayout struct {
+	// Wrap wraps cells onto multiple lines instead of truncating them with an ellipsis.
+	Wrap bool
+	// MaxWidth is the maximum width of the column, or zero for no maximum.
+	MaxWidth int
+	// Styles are the CellStyleRules of the column, whose Column is ignored.
+	Styles []CellStyleRule
+}
+
+// ColumnLayoutFunc resolves the ColumnLayout of every column of the tabular data resolved from data,
+// in order. Columns without a layout use the zero ColumnLayout.
+type ColumnLayoutFunc func(data any) []ColumnLayout
+
+// NewColumnLayoutFunc returns a ColumnLayoutFunc for the tabular data resolved by the
+// TableReflectorFunc returned from NewTableReflectorFunc with the same PrintOptions. Layouts are
+// configured with the "wrap", "truncate=N" and "style=RULES" header tag options (see
+// GenerateTableData).
+func NewColumnLayoutFunc(options PrintOptions) ColumnLayoutFunc {
+	return func(data any) []ColumnLayout {
+		v, ok := indirectValue(reflect.ValueOf(data))
+		if !ok || !v.IsValid() {
+			return nil
+		}
+		return columnLayouts(v, options)
+	}
+}
+
+// columnLayouts returns the layouts of the columns that generateTableData resolves from v, or nil
+// if none of them have a layout.
+func columnLayouts(v reflect.Value, options PrintOptions) []ColumnLayout {
+	// like processValue, the first column of a map holds its keys, and the first column of
+	// structs holds their kind if ShowKind is set
+	withKind := func(layouts []ColumnLayout) []ColumnLayout {
+		if options.ShowKind {
+			return append([]ColumnLayout{{}}, layouts...)
+		}
+		return layouts
+	}
+	if hasTableRowsInterface(v.Type()) {
+		// provided rows do not have layouts
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		return withKind(tablePlanFor(v.Type(), options).layouts())
+	case reflect.Slice, reflect.Array:
+		if elemType := indirectType(v.Type().Elem()); elemType.Kind() == reflect.Struct &&
+			!hasTableRowsInterface(elemType) {
+			return withKind(tablePlanFor(elemType, options).layouts())
+		}
+		if elems, ok := unionElements(v); ok {
+			_, layouts, _ := processUnion(elems, options)
+			return withKind(layouts)
+		}
+		return nil
+	case reflect.Map:
+		if elemType := indirectType(v.Type().Elem()); isMapValue(v) && elemType.Kind() == reflect.Struct &&
+			!hasStringerInterface(elemType) && !hasTableRowsInterface(elemType) {
+			return append([]ColumnLayout{{}}, tablePlanFor(elemType, options).layouts()...)
+		}
+		return nil
+	case reflect.Invalid, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr, reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128,
+		reflect.Chan, reflect.Func, reflect.Interface, reflect.Ptr, reflect.String,
+		reflect.UnsafePointer:
+		fallthrough
+	default:
+		return nil
+	}
+}
+
+// shrinkColumnWidths shrinks the widest columns until the sum of widths fits in the available
+// width, without shrinking any column below a width of one.
+func shrinkColumnWidths(widths []int, available int) []int {
+	total := lo.Sum(widths)
+	if total <= available || len(widths) == 0 {
+		return widths
+	}
+	// find the largest width limit that fits, so that only the widest columns are shrunk
+	limitedTotal := func(limit int) int {
+		return lo.SumBy(widths, func(width int) int { return min(width, limit) })
+	}
+	low, high := 1, slices.Max(widths)
+	for low < high {
+		mid := (low + high + 1) / 2
+		if limitedTotal(mid) <= available {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	return lo.Map(widths, func(width int, _ int) int { return min(width, low) })
+}
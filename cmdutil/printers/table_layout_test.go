@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type layoutRow struct {
+	Name        string
+	Description string `header:"DESCRIPTION,wrap"`
+	Message     string `header:"MESSAGE,truncate=8"`
+}
+
+var _ = Describe("NewColumnLayoutFunc", Label("unit"), func() {
+	It("should resolve layouts from header tag options", func() {
+		layouts := printers.NewColumnLayoutFunc(printers.PrintOptions{})([]layoutRow{})
+		Expect(layouts).To(Equal([]printers.ColumnLayout{
+			{},
+			{Wrap: true},
+			{MaxWidth: 8},
+		}))
+	})
+
+	It("should resolve layouts from the first struct of a collection", func() {
+		layouts := printers.NewColumnLayoutFunc(printers.PrintOptions{})([]any{layoutRow{}})
+		Expect(layouts).To(HaveLen(3))
+	})
+
+	It("should resolve the union of layouts of a heterogeneous collection", func() {
+		layouts := printers.NewColumnLayoutFunc(printers.PrintOptions{ShowKind: true})([]any{
+			struct{ Name string }{},
+			layoutRow{},
+		})
+		Expect(layouts).To(Equal([]printers.ColumnLayout{
+			{},
+			{},
+			{Wrap: true},
+			{MaxWidth: 8},
+		}))
+	})
+
+	It("should resolve layouts after the key column of a map of structs", func() {
+		layouts := printers.NewColumnLayoutFunc(printers.PrintOptions{})(map[string]layoutRow{})
+		Expect(layouts).To(Equal([]printers.ColumnLayout{
+			{},
+			{},
+			{Wrap: true},
+			{MaxWidth: 8},
+		}))
+	})
+
+	It("should not resolve layouts for data without columns", func() {
+		Expect(printers.NewColumnLayoutFunc(printers.PrintOptions{})([]string{"a"})).To(BeNil())
+		Expect(printers.NewColumnLayoutFunc(printers.PrintOptions{})(nil)).To(BeNil())
+		Expect(printers.NewColumnLayoutFunc(printers.PrintOptions{})(map[string]string{})).To(BeNil())
+	})
+})
+
+var _ = Describe("TablePrinter column widths", Label("unit"), func() {
+	var (
+		printer *printers.TablePrinter
+		buffer  *bytes.Buffer
+		rows    []layoutRow
+	)
+
+	lines := func() []string {
+		return strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	}
+
+	BeforeEach(func() {
+		GinkgoT().Setenv("COLUMNS", "")
+		buffer = new(bytes.Buffer)
+		if p, ok := printers.NewTablePrinter(printers.PrintOptions{}).(*printers.TablePrinter); !ok {
+			Fail("failed to create TablePrinter")
+		} else {
+			printer = p
+		}
+		rows = []layoutRow{{
+			Name:        "short",
+			Description: "a description that is long enough to wrap",
+			Message:     "a message that is truncated",
+		}}
+	})
+
+	It("should truncate columns with a maximum width", func() {
+		Expect(printer.PrintObj(rows, buffer)).To(Succeed())
+		Expect(lines()[3]).To(ContainSubstring("a messa…"))
+		Expect(lines()[3]).To(ContainSubstring("a description that is long enough to wrap"))
+	})
+
+	It("should truncate every column to MaxColumnWidth", func() {
+		printer.MaxColumnWidth = 4
+		Expect(printer.PrintObj([]struct{ Name, Message string }{{"short", "a message"}}, buffer)).
+			To(Succeed())
+		Expect(lines()[1]).To(MatchRegexp(`^│\s*NAME\s*│\s*MES…\s*│$`))
+		Expect(lines()[3]).To(MatchRegexp(`^│\s*sho…\s*│\s*a m…\s*│$`))
+	})
+
+	It("should shrink the widest columns to fit in the terminal", func() {
+		printer.TerminalWidth = 40
+		Expect(printer.PrintObj(rows, buffer)).To(Succeed())
+		for _, line := range lines() {
+			Expect(lipgloss.Width(line)).To(BeNumerically("<=", 40))
+		}
+		Expect(lines()[3]).To(MatchRegexp(`^│\s*short\s*│`))
+		// the description wraps onto multiple lines instead of being truncated
+		Expect(len(lines())).To(BeNumerically(">", 5))
+		Expect(buffer.String()).NotTo(ContainSubstring("a description that is long enough to wrap"))
+		Expect(buffer.String()).To(ContainSubstring("wrap"))
+	})
+
+	It("should truncate columns that do not wrap to fit in the terminal", func() {
+		printer.TerminalWidth = 20
+		Expect(printer.PrintObj([]struct{ A, B string }{{"x", strings.Repeat("y", 50)}}, buffer)).
+			To(Succeed())
+		Expect(lines()).To(HaveLen(5))
+		Expect(lines()[3]).To(MatchRegexp(`^│\s*x\s*│\s*y+…\s*│$`))
+		Expect(lipgloss.Width(lines()[3])).To(Equal(20))
+	})
+
+	It("should ignore $COLUMNS when the output is not a terminal", func() {
+		GinkgoT().Setenv("COLUMNS", "20")
+		Expect(printer.PrintObj([]struct{ A, B string }{{"x", strings.Repeat("y", 50)}}, buffer)).
+			To(Succeed())
+		Expect(lines()[3]).To(ContainSubstring(strings.Repeat("y", 50)))
+	})
+
+	It("should fit streamed tables to the terminal", func() {
+		printer.TerminalWidth = 20
+		stream := printer.NewStream(buffer)
+		Expect(stream.Write(struct{ A, B string }{"x", strings.Repeat("y", 50)})).To(Succeed())
+		Expect(stream.Close()).To(Succeed())
+		for _, line := range lines() {
+			Expect(lipgloss.Width(line)).To(Equal(20))
+		}
+	})
+})
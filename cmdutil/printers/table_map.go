@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+
+	"github.com/samber/lo"
+)
+
+const (
+	// mapKeyHeader is the header of the column that holds the keys of a map.
+	mapKeyHeader = "KEY"
+	// mapValueHeader is the header of the column that holds the values of a map of scalars.
+	mapValueHeader = "VALUE"
+)
+
+// processMap resolves a row for every entry of a map, sorted by key. The first column of every row
+// holds the key of the entry:
+//
+//   - the values of a map of TableRowProviders are resolved like a slice of them.
+//   - the values of a map of structs are resolved like a slice of structs.
+//   - the values of a map of maps are resolved like a slice of maps (see processMapCollection).
+//   - any other map is resolved as a KEY/VALUE table.
+func processMap(v reflect.Value, options PrintOptions) (headers []string, rows [][]string) {
+	keys := sortedMapKeys(v)
+	values := lo.Map(keys, func(key reflect.Value, _ int) reflect.Value { return v.MapIndex(key) })
+	keyCell := func(row int) string { return mapKeyString(keys[row]) }
+
+	if elemType := indirectType(v.Type().Elem()); hasTableRowsInterface(elemType) {
+		elems := lo.Map(values, func(value reflect.Value, _ int) reflect.Value {
+			if fv, ok := indirectValue(value); ok {
+				return fv
+			}
+			return reflect.Value{}
+		})
+		headers, _, rows = processUnion(elems, options)
+		return prependColumn(mapKeyHeader, headers, rows, keyCell)
+	}
+
+	if elemType := indirectType(v.Type().Elem()); elemType.Kind() == reflect.Struct &&
+		!hasStringerInterface(elemType) {
+		plan := tablePlanFor(elemType, options)
+		rows = make([][]string, 0, len(keys))
+		for _, value := range values {
+			row := make([]string, len(plan.headers))
+			if fv, ok := indirectValue(value); ok {
+				row = plan.row(fv)
+			}
+			rows = append(rows, row)
+		}
+		return prependColumn(mapKeyHeader, plan.headers, rows, keyCell)
+	}
+
+	if maps, ok := mapValues(values); ok {
+		headers, rows = processMapCollection(maps, options)
+		return prependColumn(mapKeyHeader, headers, rows, keyCell)
+	}
+
+	headers = []string{mapKeyHeader, mapValueHeader}
+	rows = make([][]string, 0, len(keys))
+	format := options.TimeFormat.cellFormatter(resolveCellValue, false)
+	for i, key := range keys {
+		value := ""
+		if fv, ok := indirectValue(values[i]); ok && fv.IsValid() {
+			value = format(fv)
+		}
+		rows = append(rows, []string{mapKeyString(key), value})
+	}
+	return headers, rows
+}
+
+// processMapCollection resolves a row for every map, like a slice of structs where every key is a
+// field. The columns are the union of the keys of every map, sorted like the keys of a single map,
+// and maps without a key leave its cell empty. Invalid values (nil maps) produce rows of empty
+// cells. Time values are formatted by [PrintOptions.TimeFormat], like time.Time fields.
+func processMapCollection(maps []reflect.Value, options PrintOptions) (headers []string, rows [][]string) {
+	var keys []reflect.Value
+	columns := map[string]int{}
+	for _, m := range maps {
+		if !m.IsValid() {
+			continue
+		}
+		for _, key := range m.MapKeys() {
+			if _, ok := columns[mapKeyString(key)]; !ok {
+				columns[mapKeyString(key)] = len(keys)
+				keys = append(keys, key)
+			}
+		}
+	}
+	slices.SortStableFunc(keys, compareMapKeys)
+	for i, key := range keys {
+		columns[mapKeyString(key)] = i
+	}
+
+	headers = lo.Map(keys, func(key reflect.Value, _ int) string {
+		return normalizeHeader(mapKeyString(key))
+	})
+	format := options.TimeFormat.cellFormatter(resolveCellValue, false)
+	rows = make([][]string, 0, len(maps))
+	for _, m := range maps {
+		row := make([]string, len(keys))
+		if m.IsValid() {
+			iter := m.MapRange()
+			for iter.Next() {
+				if fv, ok := indirectValue(iter.Value()); ok && fv.IsValid() {
+					row[columns[mapKeyString(iter.Key())]] = format(fv)
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+	return headers, rows
+}
+
+// mapValues returns the indirect values of a collection if every value is a map or nil, and at
+// least one of them is a map. Nil values are returned as invalid values.
+func mapValues(values []reflect.Value) ([]reflect.Value, bool) {
+	maps := make([]reflect.Value, 0, len(values))
+	found := false
+	for _, value := range values {
+		fv, ok := indirectValue(value)
+		switch {
+		case !ok || !fv.IsValid():
+			maps = append(maps, reflect.Value{})
+		case isMapValue(fv):
+			maps = append(maps, fv)
+			found = true
+		default:
+			return nil, false
+		}
+	}
+	return maps, found
+}
+
+// sortedMapKeys returns the keys of a map in a stable order (see compareMapKeys).
+func sortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	slices.SortStableFunc(keys, compareMapKeys)
+	return keys
+}
+
+// compareMapKeys orders numeric map keys by value, and any other keys by their string value.
+func compareMapKeys(a, b reflect.Value) int {
+	a, _ = indirectValue(a)
+	b, _ = indirectValue(b)
+	if a.IsValid() && b.IsValid() && a.Kind() == b.Kind() {
+		switch {
+		case a.CanInt():
+			return cmp.Compare(a.Int(), b.Int())
+		case a.CanUint():
+			return cmp.Compare(a.Uint(), b.Uint())
+		case a.CanFloat():
+			return cmp.Compare(a.Float(), b.Float())
+		}
+	}
+	return cmp.Compare(mapKeyString(a), mapKeyString(b))
+}
+
+// mapKeyString returns the string value of a map key.
+func mapKeyString(key reflect.Value) string {
+	if fv, ok := indirectValue(key); ok && fv.IsValid() {
+		return resolveCellValue(fv)
+	}
+	return ""
+}
@@ -0,0 +1,409 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/samber/lo"
+)
+
+// tablePlan is the compiled set of columns of a table for a struct type. Plans are compiled once
+// per type (see tablePlanFor) and reused for every row of that type, so that header tags, header
+// case conversions and interface checks are not resolved again for every field of every row.
+type tablePlan struct {
+	headers []string
+	columns []tableColumn
+}
+
+// tableColumn is a column of a table, resolved from a (possibly nested) field of a struct type.
+type tableColumn struct {
+	header string
+	// index is the sequence of field indexes leading from the struct to the column's field, like
+	// [reflect.StructField.Index] for promoted fields.
+	index []int
+	// format resolves the cell value of the column's field.
+	format cellFormatter
+	// layout configures how cells of the column are shortened.
+	layout ColumnLayout
+}
+
+// cellFormatter resolves the string value of a table cell from a non-nil field value.
+type cellFormatter func(v reflect.Value) string
+
+// tablePlanKey identifies a cached tablePlan. It includes every PrintOption that affects which
+// columns are resolved.
+//
+// The TimeFormat is compared by its *time.Location, since locations are only equal if they are
+// the same location: two time zones with the same name may have different offsets. ParseTimeFormat
+// loads every time zone once, so that parsing the same flags again reuses the cached plans.
+type tablePlanKey struct {
+	typ        reflect.Type
+	wide       bool
+	timeFormat TimeFormat
+}
+
+var (
+	// tablePlanCache holds the compiled tablePlan of every tablePlanKey seen so far.
+	tablePlanCache = struct {
+		sync.RWMutex
+		plans map[tablePlanKey]*tablePlan
+	}{plans: map[tablePlanKey]*tablePlan{}}
+	// tablePlanFor returns the tablePlan of a struct type. It is a variable so that tests can
+	// compare it against compiling a new plan every time.
+	tablePlanFor = cachedTablePlan
+)
+
+// cachedTablePlan returns the cached tablePlan of a struct type, compiling it on first use.
+// It is safe for concurrent use.
+func cachedTablePlan(typ reflect.Type, options PrintOptions) *tablePlan {
+	key := tablePlanKey{typ: typ, wide: options.Wide, timeFormat: options.TimeFormat}
+	tablePlanCache.RLock()
+	plan, ok := tablePlanCache.plans[key]
+	tablePlanCache.RUnlock()
+	if ok {
+		return plan
+	}
+	plan = compileTablePlan(typ, options)
+	tablePlanCache.Lock()
+	defer tablePlanCache.Unlock()
+	if cached, ok := tablePlanCache.plans[key]; ok {
+		return cached
+	}
+	tablePlanCache.plans[key] = plan
+	return plan
+}
+
+// compileTablePlan resolves the columns of a table from the fields of a struct type, rather than
+// from a value of that type. This guarantees that every value of the type produces a row that
+// lines up with the headers, even when some of its fields are nil.
+func compileTablePlan(typ reflect.Type, options PrintOptions) *tablePlan {
+	columns := recursiveColumnExtract(typ, nil, "", false, options, map[reflect.Type]bool{})
+	headers := make([]string, 0, len(columns))
+	for _, c := range columns {
+		headers = append(headers, c.header)
+	}
+	return &tablePlan{headers: headers, columns: columns}
+}
+
+func recursiveColumnExtract(
+	typ reflect.Type,
+	index []int,
+	prefix string,
+	inline bool,
+	options PrintOptions,
+	visiting map[reflect.Type]bool,
+) []tableColumn {
+	// guard against recursive types, like a struct embedding a pointer to itself
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	columns := []tableColumn{}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		ft := indirectType(f.Type)
+		if !f.IsExported() && !(f.Anonymous && ft.Kind() == reflect.Struct) {
+			continue
+		}
+		header := resolveHeader(f, prefix, inline)
+		// If header is marked with '-', skip this field
+		if header == "-" {
+			continue
+		}
+		tag := parseHeaderTag(f.Tag.Get("header"))
+		// Wide columns are only included in wide output
+		if tag.wide && !options.Wide {
+			continue
+		}
+		fieldIndex := append(slices.Clone(index), i)
+		switch {
+		case ft.Kind() == reflect.Struct && (f.Anonymous || tag.inline):
+			if !visiting[ft] {
+				columns = append(
+					columns,
+					recursiveColumnExtract(ft, fieldIndex, header, true, options, visiting)...,
+				)
+			}
+		case ft.Kind() == reflect.Struct, ft.Kind() == reflect.Func:
+			// nested structs and funcs can only be printed through the supported interfaces, or
+			// through a value formatter
+			if format, ok := compileStringerFormatter(ft); ok || tag.format != "" {
+				format = withValueFormatter(tag.format, lo.Ternary(ok, format, resolveCellValue))
+				if ft == timeType {
+					format = options.TimeFormat.cellFormatter(format, tag.format != "")
+				}
+				columns = append(columns, tableColumn{
+					header: header,
+					index:  fieldIndex,
+					format: format,
+					layout: tag.layout,
+				})
+			}
+		default:
+			format := withValueFormatter(tag.format, compileCellFormatter(ft))
+			if ft.Kind() == reflect.Interface {
+				// the value may be a time.Time, which is only known from the value
+				format = options.TimeFormat.cellFormatter(format, tag.format != "")
+			}
+			columns = append(columns, tableColumn{
+				header: header,
+				index:  fieldIndex,
+				format: format,
+				layout: tag.layout,
+			})
+		}
+	}
+	return columns
+}
+
+// nestedColumnExtract resolves the columns of a struct type like compileTablePlan, but keeps the
+// fields that can only be printed as nested tables or sections (see isNestedValue). The format of
+// the columns is left unset, unless the field has a "format" header tag option or is a time.Time
+// formatted by [PrintOptions.TimeFormat].
+func nestedColumnExtract(typ reflect.Type, options PrintOptions) []tableColumn {
+	return recursiveNestedColumnExtract(typ, nil, "", false, options, map[reflect.Type]bool{})
+}
+
+func recursiveNestedColumnExtract(
+	typ reflect.Type,
+	index []int,
+	prefix string,
+	inline bool,
+	options PrintOptions,
+	visiting map[reflect.Type]bool,
+) []tableColumn {
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	columns := []tableColumn{}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		ft := indirectType(f.Type)
+		if !f.IsExported() && !(f.Anonymous && ft.Kind() == reflect.Struct) {
+			continue
+		}
+		header := resolveHeader(f, prefix, inline)
+		tag := parseHeaderTag(f.Tag.Get("header"))
+		if header == "-" || (tag.wide && !options.Wide) {
+			continue
+		}
+		fieldIndex := append(slices.Clone(index), i)
+		switch {
+		case ft.Kind() == reflect.Struct && (f.Anonymous || tag.inline):
+			if !visiting[ft] {
+				columns = append(
+					columns,
+					recursiveNestedColumnExtract(ft, fieldIndex, header, true, options, visiting)...,
+				)
+			}
+		case tag.format != "", ft == timeType && !options.TimeFormat.IsZero():
+			columns = append(columns, tableColumn{
+				header: header,
+				index:  fieldIndex,
+				format: options.TimeFormat.cellFormatter(
+					withValueFormatter(tag.format, resolveCellValue),
+					tag.format != "",
+				),
+			})
+		case ft.Kind() == reflect.Func && !hasStringerInterface(ft):
+			continue
+		default:
+			columns = append(columns, tableColumn{header: header, index: fieldIndex})
+		}
+	}
+	return columns
+}
+
+// layouts returns the layout of every column of the plan.
+func (p *tablePlan) layouts() []ColumnLayout {
+	return lo.Map(p.columns, func(c tableColumn, _ int) ColumnLayout { return c.layout })
+}
+
+// row resolves a row of cells for a struct value of the plan's type, one per column. Columns whose
+// field is behind a nil pointer or interface are left empty.
+func (p *tablePlan) row(v reflect.Value) []string {
+	row := make([]string, 0, len(p.columns))
+	for _, c := range p.columns {
+		if fv, ok := fieldByIndex(v, c.index); ok {
+			row = append(row, c.format(fv))
+		} else {
+			row = append(row, "")
+		}
+	}
+	return row
+}
+
+// fieldByIndex is like [reflect.Value.FieldByIndex], but it resolves pointers and interfaces along
+// the way, and returns false instead of panicking if one of them is nil.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for _, i := range index {
+		fv, ok := indirectValue(v)
+		if !ok {
+			return fv, false
+		}
+		v = fv.Field(i)
+	}
+	return indirectValue(v)
+}
+
+// isNestedValue reports whether v should be printed as a nested table or section, rather than as
+// text, by printers that support nesting (like HTMLPrinter).
+func isNestedValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Struct:
+		_, ok := resolveStringerInterfaces(v)
+		return !ok
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return false
+		}
+		_, ok := resolveStringerInterfaces(v)
+		return !ok
+	case reflect.Invalid, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr, reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128,
+		reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.String,
+		reflect.UnsafePointer:
+		fallthrough
+	default:
+		return false
+	}
+}
+
+// isMapValue reports whether v is a map that is printed as a collection of entries, rather than as
+// text through one of the interfaces supported by resolveStringerInterfaces.
+func isMapValue(v reflect.Value) bool {
+	if v.Kind() != reflect.Map {
+		return false
+	}
+	_, ok := resolveStringerInterfaces(v)
+	return !ok
+}
+
+// hasStringerInterface reports whether values of the given type can be printed through one of the
+// interfaces supported by resolveStringerInterfaces.
+func hasStringerInterface(t reflect.Type) bool {
+	_, ok := compileStringerFormatter(t)
+	return ok
+}
+
+// compileCellFormatter returns the cellFormatter for fields of the given (indirect) type. It is
+// equivalent to calling resolveCellValue on every value, but the checks that only depend on the
+// type are done once.
+func compileCellFormatter(t reflect.Type) cellFormatter {
+	switch t.Kind() {
+	case reflect.Interface:
+		// the dynamic type is only known from the value
+		return resolveCellValue
+	case reflect.String:
+		return func(v reflect.Value) string {
+			return v.String()
+		}
+	case reflect.Invalid, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr, reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128,
+		reflect.Array, reflect.Slice, reflect.Chan, reflect.Func, reflect.Map, reflect.Ptr,
+		reflect.Struct, reflect.UnsafePointer:
+		fallthrough
+	default:
+		if format, ok := compileStringerFormatter(t); ok {
+			return format
+		}
+		return func(v reflect.Value) string {
+			if v.CanInterface() && v.Kind() != reflect.Func {
+				return formatValue(v)
+			}
+			return ""
+		}
+	}
+}
+
+// stringerResolver resolves the string value of v through one of the supported interfaces, or
+// returns false if it can't.
+type stringerResolver func(v reflect.Value) (string, bool)
+
+// compileStringerFormatter returns a cellFormatter equivalent to resolveStringValue for values of
+// the given type, or false if neither the type nor a pointer to it implements any of the
+// interfaces supported by resolveStringerInterfaces.
+func compileStringerFormatter(t reflect.Type) (cellFormatter, bool) {
+	resolvers := slices.DeleteFunc([]stringerResolver{
+		compileStringerResolver(t, func(s fmt.Stringer) (string, bool) {
+			return s.String(), true
+		}),
+		compileStringerResolver(t, func(e error) (string, bool) {
+			return e.Error(), true
+		}),
+		compileStringerResolver(t, func(m encoding.TextMarshaler) (string, bool) {
+			bts, err := m.MarshalText()
+			return string(bts), err == nil
+		}),
+		compileStringerResolver(t, func(m json.Marshaler) (string, bool) {
+			bts, err := m.MarshalJSON()
+			return string(bts), err == nil
+		}),
+	}, func(r stringerResolver) bool { return r == nil })
+	if len(resolvers) == 0 {
+		return nil, false
+	}
+	return func(v reflect.Value) string {
+		for _, resolve := range resolvers {
+			if val, ok := resolve(v); ok {
+				return val
+			}
+		}
+		if v.CanInterface() && v.Kind() != reflect.Func {
+			return fmt.Sprint(v.Interface())
+		}
+		return ""
+	}, true
+}
+
+// compileStringerResolver returns a stringerResolver that calls fn with values of type t (or
+// their address, if only a pointer to t implements T), like reflectCast. It returns nil if
+// neither t nor a pointer to it implements T.
+func compileStringerResolver[T any](t reflect.Type, fn func(T) (string, bool)) stringerResolver {
+	it := reflect.TypeFor[T]()
+	fromAddr := t.Kind() != reflect.Pointer && reflect.PointerTo(t).Implements(it)
+	fromValue := t.Implements(it)
+	if !fromAddr && !fromValue {
+		return nil
+	}
+	return func(v reflect.Value) (string, bool) {
+		switch {
+		case fromAddr && v.CanAddr() && v.Addr().CanInterface():
+			if m, ok := v.Addr().Interface().(T); ok {
+				return fn(m)
+			}
+		case fromValue && v.CanInterface():
+			if m, ok := v.Interface().(T); ok {
+				return fn(m)
+			}
+		}
+		return "", false
+	}
+}
@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type benchmarkMetadata struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Labels    map[string]string `json:"labels"`
+}
+
+type benchmarkRow struct {
+	benchmarkMetadata `header:"Meta"`
+	ID                int           `header:"ID"`
+	Status            fmt.Stringer  `header:"Status"`
+	Size              *int64        `json:"size"`
+	Created           time.Time     `json:"created_at"`
+	Timeout           time.Duration `json:"timeout"`
+	Ready             bool
+	Description       *string
+}
+
+type benchmarkStatus string
+
+func (s benchmarkStatus) String() string {
+	return "status: " + string(s)
+}
+
+func benchmarkRows(n int) []benchmarkRow {
+	rows := make([]benchmarkRow, n)
+	for i := range rows {
+		size := int64(i * 1024)
+		description := fmt.Sprintf("description of item %d", i)
+		rows[i] = benchmarkRow{
+			benchmarkMetadata: benchmarkMetadata{
+				Name:      fmt.Sprintf("item-%d", i),
+				Namespace: "default",
+				Labels:    map[string]string{"app": "web"},
+			},
+			ID:          i,
+			Status:      benchmarkStatus("ok"),
+			Size:        &size,
+			Created:     time.Unix(int64(i), 0).UTC(),
+			Timeout:     time.Duration(i) * time.Second,
+			Ready:       i%2 == 0,
+			Description: &description,
+		}
+	}
+	return rows
+}
+
+// legacyGenerateTableData is the implementation of GenerateTableData for slices of structs from
+// before table plans, which resolved the headers, tags and interfaces of every field of every row.
+// It is kept to compare its performance against table plans.
+func legacyGenerateTableData(data any) (headers []string, rows [][]string) {
+	v := reflect.ValueOf(data)
+	for i := 0; i < v.Len(); i++ {
+		if fv, ok := indirectValue(v.Index(i)); ok {
+			itemHeaders, itemRow := legacyRecursiveFieldExtract(fv, "", false)
+			if i == 0 {
+				headers = itemHeaders
+			}
+			rows = append(rows, itemRow)
+		}
+	}
+	return headers, rows
+}
+
+func legacyRecursiveFieldExtract(v reflect.Value, prefix string, inline bool) (headers, row []string) {
+	typ := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		f := typ.Field(i)
+		if fv, ok := indirectValue(v.Field(i)); ok {
+			header := resolveHeader(f, prefix, inline)
+			if header == "-" {
+				continue
+			}
+			tag := parseHeaderTag(f.Tag.Get("header"))
+			switch fv.Kind() { //nolint:exhaustive // copy of the legacy implementation
+			case reflect.String:
+				headers = append(headers, header)
+				row = append(row, fv.String())
+			case reflect.Struct:
+				if f.Anonymous || tag.inline {
+					embHeaders, embRow := legacyRecursiveFieldExtract(fv, header, true)
+					headers = append(headers, embHeaders...)
+					row = append(row, embRow...)
+				} else if val, ok := resolveStringerInterfaces(fv); ok {
+					headers = append(headers, header)
+					row = append(row, val)
+				}
+			default:
+				if val := resolveStringValue(fv); val != "" {
+					headers = append(headers, header)
+					row = append(row, val)
+				}
+			}
+		}
+	}
+	return headers, row
+}
+
+func BenchmarkGenerateTableData(b *testing.B) {
+	for _, n := range []int{100, 10000} {
+		data := benchmarkRows(n)
+
+		b.Run(fmt.Sprintf("legacy/rows=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				legacyGenerateTableData(data)
+			}
+		})
+
+		b.Run(fmt.Sprintf("uncached/rows=%d", n), func(b *testing.B) {
+			tablePlanFor = compileTablePlan
+			defer func() { tablePlanFor = cachedTablePlan }()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _, _ = GenerateTableData(data)
+			}
+		})
+
+		b.Run(fmt.Sprintf("cached/rows=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _, _ = GenerateTableData(data)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"sync"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Table plans", Label("unit"), func() {
+	It("should resolve the same table as the legacy implementation", func() {
+		data := printers.BenchmarkRows(3)
+		legacyHeaders, legacyRows := printers.LegacyGenerateTableData(data)
+		headers, rows, err := printers.GenerateTableData(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(headers).To(Equal(legacyHeaders))
+		Expect(rows).To(Equal(legacyRows))
+	})
+
+	It("should be safe for concurrent use", func() {
+		type row struct {
+			Name string
+			Wide string `header:"WIDE,wide"`
+		}
+		data := []row{{Name: "name", Wide: "wide"}}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 16; i++ {
+			wg.Add(1)
+			go func(wide bool) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				headers, _, err := printers.NewTableReflectorFunc(printers.PrintOptions{Wide: wide})(data)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(headers).To(HaveLen(map[bool]int{false: 1, true: 2}[wide]))
+			}(i%2 == 0)
+		}
+		wg.Wait()
+
+		plan := printers.CachedTablePlan(row{}, printers.PrintOptions{})
+		Expect(printers.CachedTablePlan(row{}, printers.PrintOptions{})).To(BeIdenticalTo(plan))
+	})
+})
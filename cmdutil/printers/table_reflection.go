@@ -27,15 +27,46 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/iancoleman/strcase"
+	"github.com/samber/lo"
 )
 
 var (
+	// DefaultTableReflectorFunc is the TableReflectorFunc of printers created with NewTablePrinter,
+	// NewCSVPrinter, NewMarkdownPrinter and NewPlainPrinter. While it is GenerateTableData, those
+	// printers use NewTableReflectorFunc with their PrintOptions instead, so that they honor
+	// options like [PrintOptions.Wide].
 	DefaultTableReflectorFunc TableReflectorFunc = GenerateTableData
 )
 
+// defaultTableReflectorFunc returns DefaultTableReflectorFunc, or NewTableReflectorFunc with the
+// given PrintOptions when DefaultTableReflectorFunc has not been customized.
+func defaultTableReflectorFunc(options PrintOptions) TableReflectorFunc {
+	if !isCustomTableReflectorFunc() {
+		return NewTableReflectorFunc(options)
+	}
+	return DefaultTableReflectorFunc
+}
+
+// isCustomTableReflectorFunc reports whether DefaultTableReflectorFunc has been replaced.
+func isCustomTableReflectorFunc() bool {
+	return reflect.ValueOf(DefaultTableReflectorFunc).Pointer() !=
+		reflect.ValueOf(GenerateTableData).Pointer()
+}
+
+// NewTableReflectorFunc returns a TableReflectorFunc that resolves tabular data like
+// GenerateTableData, including the columns selected by the given PrintOptions (see
+// [PrintOptions.Wide]).
+func NewTableReflectorFunc(options PrintOptions) TableReflectorFunc {
+	return func(data any) ([]string, [][]string, error) {
+		return generateTableData(data, options)
+	}
+}
+
 // TableReflectorFunc should take any struct or collection of structs and use
 // reflection to resolve tabular data. Depending on the implementation, this may
 // use struct field tags similar to encoding/json to declare table header labels.
@@ -98,23 +129,153 @@ type TableReflectorFunc func(data any) (headers []string, rows [][]string, err e
 //	// example csv output:
 //	// Field 1,Field 2,Field 3
 //	// value1,42,true
+//
+// # Table Row Interfaces
+//
+// Types can resolve their own table rows by implementing [TableRowProvider] (or
+// [TableRowsProvider] for collection types), which is preferred over resolving the rows from their
+// fields, like fmt.Stringer is preferred for cells. This applies to the input itself and to every
+// element of a collection or map.
+//
+//	func (p Pod) TableHeaders() []string { return []string{"NAME", "STATUS"} }
+//	func (p Pod) TableRow() []string     { return []string{p.Namespace + "/" + p.Name, p.status()} }
+//
+// # Columns
+//
+// Columns are resolved from the struct type rather than from each value, so every row of a
+// slice/array of structs lines up with the headers. Fields that are nil pointers (including nil
+// embedded structs) produce empty cells instead of being left out.
+// Unexported fields never produce a column, whatever their type, but the exported fields of
+// unexported embedded structs are promoted like any other embedded struct.
+// The columns of each struct type are resolved once and cached, so printing large collections only
+// costs reflection on the field values of each row.
+//
+// # Maps
+//
+// Maps are resolved for data without Go structs, like JSON decoded into any. Their entries are
+// sorted by key, and the first column (KEY) holds the key of every row:
+//
+//   - a map of structs has a row for every struct, like a slice of structs.
+//   - a map of maps has a row for every nested map, like a slice of maps.
+//   - any other map, like map[string]string, is resolved as a KEY/VALUE table.
+//
+// A slice of maps, like []map[string]any, has a row for every map. The columns are the union of
+// the keys of every map, sorted and normalized like json field tags, and maps without a key leave
+// its cell empty.
+//
+//	data := []map[string]any{{"name": "a", "createdAt": "today"}, {"name": "b", "id": 2}}
+//
+//	// headers == []string{"CREATED AT", "ID", "NAME"}
+//	// rows == [][]string{{"today", "", "a"}, {"", "2", "b"}}
+//
+// # Value Formats
+//
+// The "format=NAME[:ARGUMENT]" header tag option formats the cells of a column with the
+// ValueFormatter registered under NAME (see RegisterValueFormatter), like the age of a time.Time or
+// a size in bytes. Values the formatter can't format are printed as usual. Since header tag
+// options are separated by commas, the argument can't contain a comma.
+//
+//	Created time.Time `header:"AGE,format=age"`
+//	Size    int64     `header:"SIZE,format=bytes"`
+//	Updated time.Time `header:"UPDATED,format=time:2006-01-02"`
+//	Ready   bool      `header:"READY,format=bool:yes/no"`
+//
+// # Times
+//
+// If [PrintOptions.TimeFormat] is set (see NewTableReflectorFunc), time.Time fields are printed in
+// its time zone and layout, and so are time.Time values of interface fields and maps. Fields with
+// a "format" header tag option are converted to the time zone and then formatted by their own
+// ValueFormatter.
+//
+// # Heterogeneous Collections
+//
+// A collection of different types, like a []any holding structs of different types and maps, has
+// a row for every element. The columns are the union of the columns of every type, in order of
+// first appearance, and elements without a column leave its cell empty.
+//
+// If [PrintOptions.ShowKind] is set (see NewTableReflectorFunc), the first column (KIND) of
+// structs and collections of structs holds the name of the type of every row.
+//
+// # Wide Columns
+//
+// Fields with the "wide" header tag option are only included when [PrintOptions.Wide] is set
+// (see NewTableReflectorFunc), which is how the "-o wide" output format shows additional columns.
+// GenerateTableData never includes them.
+//
+//	MyField `header:"NODE,wide"`
+//
+// # Column Layout
+//
+// The "wrap" and "truncate=N" header tag options configure how [TablePrinter] shortens the cells of
+// a column that is too wide for the terminal (see NewColumnLayoutFunc). They do not affect the
+// resolved tabular data.
+//
+//	Description string `header:"DESCRIPTION,wrap"`
+//	Message     string `header:"MESSAGE,truncate=40"`
+//
+// # Cell Styles
+//
+// The "style=RULES" header tag option styles the cells of a column in [TablePrinter] output, with
+// CellStyleRules separated by semicolons. Each rule is a condition and a style, like
+// ParseCellStyleRule without the column, where a condition without an operator matches cells that
+// are exactly the condition. It does not affect the resolved tabular data.
+//
+//	Status string `header:"STATUS,style=Failed:red;Pending:yellow;Running:green"`
+//	CPU    int    `header:"CPU %,style=>=75:yellow;>=90:red+bold"`
 func GenerateTableData(data any) (headers []string, rows [][]string, _ error) {
+	return generateTableData(data, PrintOptions{})
+}
+
+func generateTableData(data any, options PrintOptions) (headers []string, rows [][]string, _ error) {
 	headers = []string{}
 	rows = [][]string{}
 	if data == nil {
 		return headers, rows, nil
 	}
 	if v, ok := indirectValue(reflect.ValueOf(data)); ok {
-		headers, rows = processValue(v)
+		headers, rows = processValue(v, options)
 	}
 	return headers, rows, nil
 }
 
-func processValue(v reflect.Value) (headers []string, rows [][]string) {
+func processValue(v reflect.Value, options PrintOptions) (headers []string, rows [][]string) {
 	headers = []string{}
 	rows = [][]string{}
+	if headers, rows, ok := resolveTableRows(v); ok {
+		return headers, rows
+	}
+	if headers, row, ok := resolveTableRow(v); ok {
+		if options.ShowKind {
+			return prependColumn(kindHeader, headers, [][]string{row}, func(int) string { return kindOf(v.Type()) })
+		}
+		return headers, [][]string{row}
+	}
+	if isMapValue(v) {
+		return processMap(v, options)
+	}
 	switch v.Kind() {
 	case reflect.Slice, reflect.Array:
+		if elemType := indirectType(v.Type().Elem()); elemType.Kind() == reflect.Struct &&
+			!hasTableRowsInterface(elemType) {
+			headers, rows = processStructCollection(v, tablePlanFor(elemType, options))
+			if options.ShowKind {
+				return prependColumn(kindHeader, headers, rows, func(int) string { return kindOf(elemType) })
+			}
+			return headers, rows
+		}
+		if maps, ok := mapValues(lo.Times(v.Len(), v.Index)); ok {
+			// like nil structs, nil maps are left out
+			return processMapCollection(lo.Filter(maps, func(m reflect.Value, _ int) bool {
+				return m.IsValid() && !m.IsNil()
+			}), options)
+		}
+		if elems, ok := unionElements(v); ok {
+			headers, _, rows = processUnion(elems, options)
+			if options.ShowKind {
+				return prependColumn(kindHeader, headers, rows, func(i int) string { return kindOf(elems[i].Type()) })
+			}
+			return headers, rows
+		}
 		headers = []string{""}
 		for i := 0; i < v.Len(); i++ {
 			if fv, ok := indirectValue(v.Index(i)); ok {
@@ -122,13 +283,13 @@ func processValue(v reflect.Value) (headers []string, rows [][]string) {
 				case reflect.String:
 					rows = append(rows, []string{reflect.Indirect(fv).String()})
 				case reflect.Struct:
-					itemHeaders, itemRow := recursiveFieldExtract(fv, "", false)
+					plan := tablePlanFor(fv.Type(), options)
 					if i == 0 {
-						headers = itemHeaders
+						headers = slices.Clone(plan.headers)
 					}
-					rows = append(rows, itemRow)
+					rows = append(rows, plan.row(fv))
 				case reflect.Array, reflect.Slice:
-					// TODO: someday support nested tables, like html tables
+					// nested collections are printed as text, see HTMLPrinter for nested tables
 					fallthrough
 				case reflect.Invalid,
 					reflect.Bool,
@@ -162,9 +323,12 @@ func processValue(v reflect.Value) (headers []string, rows [][]string) {
 	case reflect.String:
 		rows = [][]string{{v.String()}}
 	case reflect.Struct:
-		h, r := recursiveFieldExtract(v, "", false)
-		headers = h
-		rows = append(rows, r)
+		plan := tablePlanFor(v.Type(), options)
+		headers = slices.Clone(plan.headers)
+		rows = append(rows, plan.row(v))
+		if options.ShowKind {
+			return prependColumn(kindHeader, headers, rows, func(int) string { return kindOf(v.Type()) })
+		}
 	case reflect.Invalid, reflect.Chan, reflect.Ptr, reflect.UnsafePointer:
 		break
 	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
@@ -182,15 +346,38 @@ func processValue(v reflect.Value) (headers []string, rows [][]string) {
 	return headers, rows
 }
 
+// processStructCollection resolves a row for every non-nil element of a slice or array of structs.
+// All rows share the same columns, which are resolved once from the element type.
+func processStructCollection(v reflect.Value, plan *tablePlan) (headers []string, rows [][]string) {
+	headers = slices.Clone(plan.headers)
+	rows = make([][]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		if fv, ok := indirectValue(v.Index(i)); ok {
+			rows = append(rows, plan.row(fv))
+		}
+	}
+	return headers, rows
+}
+
 func resolveStringValue(v reflect.Value) string {
 	if val, ok := resolveStringerInterfaces(v); ok {
 		return val
 	} else if v.CanInterface() && v.Kind() != reflect.Func {
-		return fmt.Sprint(v.Interface())
+		return formatValue(v)
 	}
 	return ""
 }
 
+// formatValue formats v like fmt.Sprint, except that floats are never printed in exponent form.
+// Numbers decoded from JSON into an any are float64, so an ID of 12345678 is printed as 12345678
+// rather than 1.2345678e+07.
+func formatValue(v reflect.Value) string {
+	if kind := v.Kind(); kind == reflect.Float32 || kind == reflect.Float64 {
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
 // indirectValue returns the indirect value of a [reflect.Value], handling pointers and interfaces
 // (including nested, like double pointers or interfaces of interfaces)
 // Returns false if the value is nil.
@@ -207,40 +394,69 @@ func indirectValue(v reflect.Value) (val reflect.Value, isNotNil bool) {
 	return val, true
 }
 
-func recursiveFieldExtract(
-	v reflect.Value,
-	prefix string,
-	inline bool,
-) (headers, row []string) {
-	typ := v.Type()
-	for i := 0; i < v.NumField(); i++ {
-		f := typ.Field(i)
-		if fv, ok := indirectValue(v.Field(i)); ok {
-			header := resolveHeader(f, prefix, inline)
-			// If header is marked with '-', skip this field
-			if header == "-" {
-				continue
+// indirectType returns the type that a (possibly nested) pointer type points to.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// headerTag is a parsed `header:"NAME,option,..."` struct field tag.
+type headerTag struct {
+	// name is the header label, which may be empty or "-".
+	name string
+	// inline flattens the fields of a nested struct into the parent table, prefixed by name.
+	inline bool
+	// wide only includes the field when printing wide output.
+	wide bool
+	// layout configures how cells of the column are shortened and styled, from the "wrap",
+	// "truncate=N" and "style=RULES" options.
+	layout ColumnLayout
+	// format is the spec of the ValueFormatter of the column, from the "format=NAME[:ARGUMENT]"
+	// option.
+	format string
+}
+
+func parseHeaderTag(tag string) headerTag {
+	name, opts, _ := strings.Cut(tag, ",")
+	parsed := headerTag{name: name}
+	for _, opt := range strings.Split(opts, ",") {
+		opt, value, _ := strings.Cut(opt, "=")
+		switch opt {
+		case "inline":
+			parsed.inline = true
+		case "wide":
+			parsed.wide = true
+		case "wrap":
+			parsed.layout.Wrap = true
+		case "truncate":
+			if width, err := strconv.Atoi(value); err == nil && width > 0 {
+				parsed.layout.MaxWidth = width
 			}
-			headers, row = extractField(fv, f, header, headers, row)
+		case "format":
+			parsed.format = value
+		case "style":
+			parsed.layout.Styles = parseTagCellStyleRules(value)
 		}
 	}
-	return headers, row
+	return parsed
 }
 
 func resolveHeader(f reflect.StructField, prefix string, inline bool) string {
 	var header string
 	tag := f.Tag.Get("header")
 	if tag != "" {
-		header = strings.Split(tag, ",")[0]
+		header = parseHeaderTag(tag).name
 	} else if tag = f.Tag.Get("json"); tag != "" {
 		header = strings.Split(tag, ",")[0]
 		if header == "-" {
 			return "-"
 		}
-		header = strcase.ToScreamingDelimited(header, ' ', "", true)
+		header = normalizeHeader(header)
 	}
 	if header == "" {
-		header = strcase.ToScreamingDelimited(f.Name, ' ', "", true)
+		header = normalizeHeader(f.Name)
 	}
 	if inline {
 		header = prefix + " " + header
@@ -248,40 +464,28 @@ func resolveHeader(f reflect.StructField, prefix string, inline bool) string {
 	return header
 }
 
-func extractField(
-	fv reflect.Value,
-	f reflect.StructField,
-	header string,
-	headers, row []string,
-) ([]string, []string) {
-	switch fv.Kind() {
+// normalizeHeader returns the header of a json field name, field name or map key, normalized to
+// all-caps words, like "MY FIELD".
+func normalizeHeader(name string) string {
+	return strcase.ToScreamingDelimited(name, ' ', "", true)
+}
+
+func resolveCellValue(v reflect.Value) string {
+	switch v.Kind() {
 	case reflect.String:
-		headers = append(headers, header)
-		row = append(row, reflect.Indirect(fv).String())
+		return v.String()
 	case reflect.Struct:
-		if f.Anonymous || strings.Contains(f.Tag.Get("header"), ",inline") {
-			embHeaders, embRow := recursiveFieldExtract(fv, header, true)
-			headers = append(headers, embHeaders...)
-			row = append(row, embRow...)
-		} else if val, ok := resolveStringerInterfaces(fv); ok {
-			headers = append(headers, header)
-			row = append(row, val)
-		}
-	case reflect.Array, reflect.Slice:
-		// TODO: someday support nested tables, like html tables
-		fallthrough
+		val, _ := resolveStringerInterfaces(v)
+		return val
 	case reflect.Invalid, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
 		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
 		reflect.Uintptr, reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128,
-		reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.UnsafePointer:
+		reflect.Array, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface, reflect.Map,
+		reflect.Ptr, reflect.UnsafePointer:
 		fallthrough
 	default:
-		if val := resolveStringValue(fv); val != "" {
-			headers = append(headers, header)
-			row = append(row, val)
-		}
+		return resolveStringValue(v)
 	}
-	return headers, row
 }
 
 func resolveStringerInterfaces(v reflect.Value) (string, bool) {
@@ -204,6 +204,21 @@ var _ = Describe("Table Reflection", Label("unit"), func() {
 			ExpectedRows:    [][]string{{"value1", "42"}},
 			ShouldError:     false,
 		}),
+		// - unexported fields
+		Entry("a struct with unexported fields", TestCase{
+			Input: struct {
+				Name   string
+				secret string
+				count  int
+			}{
+				Name:   "name",
+				secret: "secret",
+				count:  1,
+			},
+			ExpectedHeaders: []string{"NAME"},
+			ExpectedRows:    [][]string{{"name"}},
+			ShouldError:     false,
+		}),
 		// --- primitive types
 		// - all primitive types as field values
 		Entry("a struct with all primitive types", TestCase{
@@ -252,8 +267,22 @@ var _ = Describe("Table Reflection", Label("unit"), func() {
 				Field3: nil,
 				Field4: nil,
 			},
-			ExpectedHeaders: nil,
-			ExpectedRows:    [][]string{nil},
+			ExpectedHeaders: []string{"Field 1", "Field 2", "Field 3", "Field 4"},
+			ExpectedRows:    [][]string{{"", "", "", ""}},
+			ShouldError:     false,
+		}),
+		// - some primitive types as nil field pointers in a slice
+		Entry("a slice of structs with some nil field pointers", TestCase{
+			Input: []struct {
+				Field1 *string `header:"Field 1"`
+				Field2 *int    `header:"Field 2"`
+				Field3 *bool   `header:"Field 3"`
+			}{
+				{Field1: nil, Field2: lo.ToPtr(42), Field3: nil},
+				{Field1: lo.ToPtr("value1"), Field2: nil, Field3: lo.ToPtr(true)},
+			},
+			ExpectedHeaders: []string{"Field 1", "Field 2", "Field 3"},
+			ExpectedRows:    [][]string{{"", "42", ""}, {"value1", "", "true"}},
 			ShouldError:     false,
 		}),
 		// --- primitive types in collections (slice/array)
@@ -496,8 +525,21 @@ var _ = Describe("Table Reflection", Label("unit"), func() {
 				TestStruct: nil,
 				Field3:     true,
 			},
-			ExpectedHeaders: []string{"Field 3"},
-			ExpectedRows:    [][]string{{"true"}},
+			ExpectedHeaders: []string{"TestStruct Field 1", "TestStruct Field 2", "Field 3"},
+			ExpectedRows:    [][]string{{"", "", "true"}},
+			ShouldError:     false,
+		}),
+		// - slice of structs with embedded pointer struct fields, some with nil values
+		Entry("a slice of structs with embedded pointer struct fields with some nil values", TestCase{
+			Input: []struct {
+				*TestStruct `header:"TestStruct"`
+				Field3      bool `header:"Field 3"`
+			}{
+				{TestStruct: nil, Field3: true},
+				{TestStruct: &TestStruct{Field1: "value1", Field2: 42}, Field3: false},
+			},
+			ExpectedHeaders: []string{"TestStruct Field 1", "TestStruct Field 2", "Field 3"},
+			ExpectedRows:    [][]string{{"", "", "true"}, {"value1", "42", "false"}},
 			ShouldError:     false,
 		}),
 		// --- nested structs (structs with named struct fields)
@@ -1102,6 +1144,19 @@ var _ = Describe("Table Reflection", Label("unit"), func() {
 			ExpectedRows:    [][]string{{"true"}},
 			ShouldError:     false,
 		}),
+		// -- wide tag
+		Entry("a struct with a wide field", TestCase{
+			Input: struct {
+				Field1 string `header:"Field 1"`
+				Field2 string `header:"Field 2,wide"`
+			}{
+				Field1: "value1",
+				Field2: "value2",
+			},
+			ExpectedHeaders: []string{"Field 1"},
+			ExpectedRows:    [][]string{{"value1"}},
+			ShouldError:     false,
+		}),
 		// --- edge cases
 		// - double pointer interface
 		Entry("a double pointer interface", TestCase{
@@ -1123,6 +1178,39 @@ var _ = Describe("Table Reflection", Label("unit"), func() {
 			ExpectedRows:    [][]string{{"value1", "42"}},
 			ShouldError:     false,
 		}),
+		// - empty struct slice
+		Entry("an empty struct slice", TestCase{
+			Input:           []TestStruct{},
+			ExpectedHeaders: []string{"Field 1", "Field 2"},
+			ExpectedRows:    [][]string{},
+			ShouldError:     false,
+		}),
+		// - struct slice with an empty stringer field
+		Entry("a struct slice with a stringer field that is sometimes empty", TestCase{
+			Input: []struct {
+				Field1 FmtStringer `header:"Field 1"`
+				Field2 int         `header:"Field 2"`
+			}{
+				{Field1: func() string { return "" }, Field2: 42},
+				{Field1: func() string { return "value1" }, Field2: 43},
+			},
+			ExpectedHeaders: []string{"Field 1", "Field 2"},
+			ExpectedRows:    [][]string{{"", "42"}, {"value1", "43"}},
+			ShouldError:     false,
+		}),
+		// - recursive struct type
+		Entry("a recursive struct type", TestCase{
+			Input: func() any {
+				type Node struct {
+					*Node
+					Name string
+				}
+				return Node{Name: "value1"}
+			}(),
+			ExpectedHeaders: []string{"NAME"},
+			ExpectedRows:    [][]string{{"value1"}},
+			ShouldError:     false,
+		}),
 		// - nil pointer struct
 		Entry("a nil pointer struct", TestCase{
 			Input:           (*TestStruct)(nil),
@@ -1130,5 +1218,182 @@ var _ = Describe("Table Reflection", Label("unit"), func() {
 			ExpectedRows:    [][]string{},
 			ShouldError:     false,
 		}),
+		// --- heterogeneous collections
+		// - slice of different struct types
+		Entry("a slice of different struct types", TestCase{
+			Input: []any{
+				TestStruct{Field1: "value1", Field2: 42},
+				struct {
+					Field3 bool   `header:"Field 3"`
+					Field1 string `header:"Field 1"`
+				}{Field3: true, Field1: "value2"},
+				nil,
+				&TestStruct{Field1: "value3", Field2: 43},
+			},
+			ExpectedHeaders: []string{"Field 1", "Field 2", "Field 3"},
+			ExpectedRows: [][]string{
+				{"value1", "42", ""},
+				{"value2", "", "true"},
+				{"value3", "43", ""},
+			},
+			ShouldError: false,
+		}),
+		// - slice of structs and maps
+		Entry("a slice of structs and maps", TestCase{
+			Input: []any{
+				map[string]any{"name": "a", "extra": 1},
+				struct{ Name, Node string }{Name: "b", Node: "node-b"},
+			},
+			ExpectedHeaders: []string{"EXTRA", "NAME", "NODE"},
+			ExpectedRows:    [][]string{{"1", "a", ""}, {"", "b", "node-b"}},
+			ShouldError:     false,
+		}),
+		// - slice of structs with duplicate headers
+		Entry("a slice of structs with duplicate headers", TestCase{
+			Input: []any{
+				struct {
+					A string `header:"X"`
+					B string `header:"X"`
+				}{A: "a", B: "b"},
+				TestStruct{Field1: "value1"},
+			},
+			ExpectedHeaders: []string{"X", "X", "Field 1", "Field 2"},
+			ExpectedRows:    [][]string{{"a", "b", "", ""}, {"", "", "value1", "0"}},
+			ShouldError:     false,
+		}),
+		// --- maps
+		// - map of structs
+		Entry("a map of structs", TestCase{
+			Input: map[string]*TestStruct{
+				"b": {Field1: "value2", Field2: 43},
+				"a": {Field1: "value1", Field2: 42},
+				"c": nil,
+			},
+			ExpectedHeaders: []string{"KEY", "Field 1", "Field 2"},
+			ExpectedRows:    [][]string{{"a", "value1", "42"}, {"b", "value2", "43"}, {"c", "", ""}},
+			ShouldError:     false,
+		}),
+		// - map of scalars
+		Entry("a map of scalars", TestCase{
+			Input:           map[string]int{"b": 2, "a": 1},
+			ExpectedHeaders: []string{"KEY", "VALUE"},
+			ExpectedRows:    [][]string{{"a", "1"}, {"b", "2"}},
+			ShouldError:     false,
+		}),
+		// - map with numeric keys
+		Entry("a map with numeric keys", TestCase{
+			Input:           map[int]string{10: "ten", 9: "nine", -1: "minus one"},
+			ExpectedHeaders: []string{"KEY", "VALUE"},
+			ExpectedRows:    [][]string{{"-1", "minus one"}, {"9", "nine"}, {"10", "ten"}},
+			ShouldError:     false,
+		}),
+		// - empty map
+		Entry("an empty map", TestCase{
+			Input:           map[string]string{},
+			ExpectedHeaders: []string{"KEY", "VALUE"},
+			ExpectedRows:    [][]string{},
+			ShouldError:     false,
+		}),
+		// - map of maps
+		Entry("a map of maps", TestCase{
+			Input: map[string]map[string]any{
+				"web":    {"replicas": 3, "image": "nginx"},
+				"worker": {"replicas": 1, "command": "run"},
+			},
+			ExpectedHeaders: []string{"KEY", "COMMAND", "IMAGE", "REPLICAS"},
+			ExpectedRows:    [][]string{{"web", "", "nginx", "3"}, {"worker", "run", "", "1"}},
+			ShouldError:     false,
+		}),
+		// - slice of maps
+		Entry("a slice of maps", TestCase{
+			Input: []map[string]any{
+				{"name": "a", "createdAt": "today"},
+				nil,
+				{"name": "b", "id": 2, "tags": []string{"x", "y"}},
+			},
+			ExpectedHeaders: []string{"CREATED AT", "ID", "NAME", "TAGS"},
+			ExpectedRows:    [][]string{{"today", "", "a", ""}, {"", "2", "b", "[x y]"}},
+			ShouldError:     false,
+		}),
+		// - decoded json
+		Entry("decoded json", TestCase{
+			Input: func() any {
+				var data any
+				lo.Must0(json.Unmarshal([]byte(`[{"id":1,"name":"a"},{"id":2,"labels":{"app":"b"}}]`), &data))
+				return data
+			}(),
+			ExpectedHeaders: []string{"ID", "LABELS", "NAME"},
+			ExpectedRows:    [][]string{{"1", "", "a"}, {"2", "map[app:b]", ""}},
+			ShouldError:     false,
+		}),
+		// - decoded json with large numbers
+		Entry("decoded json with large numbers", TestCase{
+			Input: func() any {
+				var data any
+				lo.Must0(json.Unmarshal([]byte(`[{"id":12345678,"size":1048576,"ratio":0.25}]`), &data))
+				return data
+			}(),
+			ExpectedHeaders: []string{"ID", "RATIO", "SIZE"},
+			ExpectedRows:    [][]string{{"12345678", "0.25", "1048576"}},
+			ShouldError:     false,
+		}),
+		// - decoded json object
+		Entry("a decoded json object", TestCase{
+			Input: func() any {
+				var data any
+				lo.Must0(json.Unmarshal([]byte(`{"name":"a","count":2,"ok":true}`), &data))
+				return data
+			}(),
+			ExpectedHeaders: []string{"KEY", "VALUE"},
+			ExpectedRows:    [][]string{{"count", "2"}, {"name", "a"}, {"ok", "true"}},
+			ShouldError:     false,
+		}),
 	)
+
+	Describe("NewTableReflectorFunc", func() {
+		type WideStruct struct {
+			Field1 string     `header:"Field 1"`
+			Field2 string     `header:"Field 2,wide"`
+			Field3 TestStruct `header:"Test,inline,wide"`
+		}
+
+		input := []WideStruct{
+			{Field1: "value1", Field2: "value2", Field3: TestStruct{Field1: "value3", Field2: 42}},
+		}
+
+		It("should exclude wide fields by default", func() {
+			headers, rows, err := printers.NewTableReflectorFunc(printers.PrintOptions{})(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(headers).To(Equal([]string{"Field 1"}))
+			Expect(rows).To(Equal([][]string{{"value1"}}))
+		})
+
+		It("should prepend a KIND column when ShowKind is set", func() {
+			reflector := printers.NewTableReflectorFunc(printers.PrintOptions{ShowKind: true})
+
+			headers, rows, err := reflector([]any{TestStruct{Field1: "value1"}, WideStruct{Field1: "value2"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(headers).To(Equal([]string{"KIND", "Field 1", "Field 2"}))
+			Expect(rows).To(Equal([][]string{{"TestStruct", "value1", "0"}, {"WideStruct", "value2", ""}}))
+
+			headers, rows, err = reflector(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(headers).To(Equal([]string{"KIND", "Field 1"}))
+			Expect(rows).To(Equal([][]string{{"WideStruct", "value1"}}))
+
+			headers, rows, err = reflector(TestStruct{Field1: "value1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(headers).To(Equal([]string{"KIND", "Field 1", "Field 2"}))
+			Expect(rows).To(Equal([][]string{{"TestStruct", "value1", "0"}}))
+		})
+
+		It("should include wide fields when Wide is set", func() {
+			headers, rows, err := printers.NewTableReflectorFunc(
+				printers.PrintOptions{Wide: true},
+			)(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(headers).To(Equal([]string{"Field 1", "Field 2", "Test Field 1", "Test Field 2"}))
+			Expect(rows).To(Equal([][]string{{"value1", "value2", "value3", "42"}}))
+		})
+	})
 })
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"reflect"
+)
+
+// TableRowProvider is implemented by types that resolve their own table row, rather than having it
+// resolved from their fields by GenerateTableData. This is useful for types whose table view is
+// computed, like joined fields or a status derived from other fields, without declaring a separate
+// struct just to print them.
+//
+// Like fmt.Stringer for cells, it is preferred over reflection for the value itself and for every
+// element of a collection of values.
+type TableRowProvider interface {
+	// TableHeaders returns the headers of the columns of the row.
+	TableHeaders() []string
+	// TableRow returns the cells of the row, one per header.
+	TableRow() []string
+}
+
+// TableRowsProvider is implemented by types that resolve their own table rows, like a collection
+// type that prints a summary row. It is preferred over TableRowProvider and reflection.
+type TableRowsProvider interface {
+	// TableHeaders returns the headers of the columns of the rows.
+	TableHeaders() []string
+	// TableRows returns the rows, with one cell per header.
+	TableRows() [][]string
+}
+
+var (
+	tableRowProviderType  = reflect.TypeOf((*TableRowProvider)(nil)).Elem()
+	tableRowsProviderType = reflect.TypeOf((*TableRowsProvider)(nil)).Elem()
+)
+
+// resolveTableRows resolves the headers and rows of a TableRowsProvider, or returns false if v
+// does not implement it.
+func resolveTableRows(v reflect.Value) (headers []string, rows [][]string, ok bool) {
+	if !v.CanInterface() {
+		return nil, nil, false
+	}
+	if p, ok := reflectCast[TableRowsProvider](v, v.CanAddr()); ok {
+		headers = p.TableHeaders()
+		return headers, alignRows(p.TableRows(), len(headers)), true
+	}
+	return nil, nil, false
+}
+
+// resolveTableRow resolves the headers and row of a TableRowProvider, or returns false if v does
+// not implement it.
+func resolveTableRow(v reflect.Value) (headers []string, row []string, ok bool) {
+	if !v.CanInterface() {
+		return nil, nil, false
+	}
+	if p, ok := reflectCast[TableRowProvider](v, v.CanAddr()); ok {
+		headers = p.TableHeaders()
+		return headers, alignRows([][]string{p.TableRow()}, len(headers))[0], true
+	}
+	return nil, nil, false
+}
+
+// isTableRowProvider reports whether v implements TableRowProvider.
+func isTableRowProvider(v reflect.Value) bool {
+	if !v.CanInterface() {
+		return false
+	}
+	_, ok := reflectCast[TableRowProvider](v, v.CanAddr())
+	return ok
+}
+
+// hasTableRowsInterface reports whether values of the given type, or pointers to them, implement
+// TableRowProvider or TableRowsProvider.
+func hasTableRowsInterface(t reflect.Type) bool {
+	return t.Implements(tableRowProviderType) || reflect.PointerTo(t).Implements(tableRowProviderType) ||
+		t.Implements(tableRowsProviderType) || reflect.PointerTo(t).Implements(tableRowsProviderType)
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"strings"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type rowPod struct {
+	Namespace string
+	Name      string
+	Ready     int
+	Total     int
+}
+
+func (p rowPod) TableHeaders() []string {
+	return []string{"NAME", "READY"}
+}
+
+func (p rowPod) TableRow() []string {
+	return []string{p.Namespace + "/" + p.Name, strings.Repeat("+", p.Ready)}
+}
+
+type rowService struct {
+	Name string
+}
+
+func (s *rowService) TableHeaders() []string {
+	return []string{"NAME", "TYPE"}
+}
+
+func (s *rowService) TableRow() []string {
+	return []string{s.Name, "ClusterIP", "ignored"}
+}
+
+type rowPods []rowPod
+
+func (p rowPods) TableHeaders() []string {
+	return []string{"PODS"}
+}
+
+func (p rowPods) TableRows() [][]string {
+	return [][]string{{strings.Repeat("*", len(p))}}
+}
+
+var _ = Describe("TableRowProvider", Label("unit"), func() {
+	It("should resolve the row of a single value", func() {
+		headers, rows, err := printers.GenerateTableData(rowPod{Namespace: "default", Name: "web", Ready: 2})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(headers).To(Equal([]string{"NAME", "READY"}))
+		Expect(rows).To(Equal([][]string{{"default/web", "++"}}))
+	})
+
+	It("should resolve the rows of a collection", func() {
+		headers, rows, err := printers.GenerateTableData([]rowPod{
+			{Namespace: "default", Name: "web", Ready: 1},
+			{Namespace: "kube-system", Name: "dns"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(headers).To(Equal([]string{"NAME", "READY"}))
+		Expect(rows).To(Equal([][]string{{"default/web", "+"}, {"kube-system/dns", ""}}))
+	})
+
+	It("should resolve rows with pointer receivers and align them to the headers", func() {
+		headers, rows, err := printers.GenerateTableData([]*rowService{{Name: "api"}, nil})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(headers).To(Equal([]string{"NAME", "TYPE"}))
+		Expect(rows).To(Equal([][]string{{"api", "ClusterIP"}}))
+
+		headers, rows, err = printers.GenerateTableData([]rowService{{Name: "api"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(headers).To(Equal([]string{"NAME", "TYPE"}))
+		Expect(rows).To(Equal([][]string{{"api", "ClusterIP"}}))
+	})
+
+	It("should union the columns of different types", func() {
+		headers, rows, err := printers.NewTableReflectorFunc(printers.PrintOptions{ShowKind: true})([]any{
+			rowPod{Namespace: "default", Name: "web"},
+			&rowService{Name: "api"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(headers).To(Equal([]string{"KIND", "NAME", "READY", "TYPE"}))
+		Expect(rows).To(Equal([][]string{
+			{"rowPod", "default/web", "", ""},
+			{"rowService", "api", "", "ClusterIP"},
+		}))
+	})
+
+	It("should resolve the rows of a map", func() {
+		headers, rows, err := printers.GenerateTableData(map[string]rowPod{
+			"b": {Namespace: "default", Name: "b"},
+			"a": {Namespace: "default", Name: "a"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(headers).To(Equal([]string{"KEY", "NAME", "READY"}))
+		Expect(rows).To(Equal([][]string{{"a", "default/a", ""}, {"b", "default/b", ""}}))
+	})
+
+	It("should prefer TableRowsProvider over the rows of the elements", func() {
+		headers, rows, err := printers.GenerateTableData(rowPods{{}, {}, {}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(headers).To(Equal([]string{"PODS"}))
+		Expect(rows).To(Equal([][]string{{"***"}}))
+	})
+
+	It("should not resolve column layouts from the fields of the type", func() {
+		Expect(printers.NewColumnLayoutFunc(printers.PrintOptions{})([]rowPod{{}})).
+			To(Equal([]printers.ColumnLayout{{}, {}}))
+		Expect(printers.NewColumnLayoutFunc(printers.PrintOptions{})(rowPod{})).To(BeNil())
+	})
+})
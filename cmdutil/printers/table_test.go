@@ -26,6 +26,7 @@ import (
 	"bytes"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/exp/teatest"
 	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
 
@@ -67,6 +68,40 @@ var _ = Describe("TablePrinter", Label("unit"), func() {
 			})
 		})
 
+		Context("when given rows that do not line up with the headers", func() {
+			It("should print the rows aligned to the headers", func() {
+				printer.TableReflectorFunc = func(any) ([]string, [][]string, error) {
+					return []string{"A", "B"}, [][]string{{"1"}, {"1", "2", "3"}}, nil
+				}
+				Expect(printer.PrintObj(struct{}{}, buffer)).To(Succeed())
+				lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+				Expect(lines).To(HaveLen(6))
+				Expect(lines[3]).To(MatchRegexp(`^│\s*1\s*│\s*│$`))
+				Expect(lines[4]).To(MatchRegexp(`^│\s*1\s*│\s*2\s*│$`))
+			})
+		})
+
+		Context("when given cells in mixed scripts or with ANSI escape sequences", func() {
+			It("should size the columns by display width", func() {
+				printer.TableReflectorFunc = func(any) ([]string, [][]string, error) {
+					return []string{"NAME", "VALUE"}, [][]string{
+						{"日本語", "x"},
+						{"🎉🎉", "x"},
+						{"Jose\u0301", "x"},
+						{"\x1b[31mred\x1b[0m", "x"},
+					}, nil
+				}
+				Expect(printer.PrintObj(struct{}{}, buffer)).To(Succeed())
+				lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+				Expect(lines).To(HaveLen(8))
+				for _, line := range lines {
+					Expect(lipgloss.Width(line)).To(Equal(lipgloss.Width(lines[0])))
+				}
+				// the widest cell is 日本語, which takes 6 columns, plus 2 for the cell padding
+				Expect(lines[0]).To(HavePrefix("┌" + strings.Repeat("─", 8) + "┬"))
+			})
+		})
+
 		Context("when given an invalid object", func() {
 			It("should not print anything", func() {
 				obj := make(chan int)
@@ -84,4 +119,19 @@ var _ = Describe("TablePrinter", Label("unit"), func() {
 			})
 		})
 	})
+
+	Describe("NewTablePrinter", func() {
+		It("should use a customized DefaultTableReflectorFunc", func() {
+			defaultTableReflectorFunc := printers.DefaultTableReflectorFunc
+			DeferCleanup(func() { printers.DefaultTableReflectorFunc = defaultTableReflectorFunc })
+			printers.DefaultTableReflectorFunc = func(any) ([]string, [][]string, error) {
+				return []string{"CUSTOM"}, [][]string{{"row"}}, nil
+			}
+			printer := printers.NewTablePrinter(printers.PrintOptions{})
+			Expect(printer.PrintObj(struct{ Key string }{Key: "key"}, buffer)).To(Succeed())
+			Expect(buffer.String()).To(ContainSubstring("CUSTOM"))
+			Expect(buffer.String()).To(ContainSubstring("row"))
+			Expect(buffer.String()).NotTo(ContainSubstring("KEY"))
+		})
+	})
 })
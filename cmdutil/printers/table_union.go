@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"reflect"
+
+	"github.com/samber/lo"
+)
+
+// kindHeader is the header of the column that holds the kind of every row (see
+// [PrintOptions.ShowKind]).
+const kindHeader = "KIND"
+
+// unionKey identifies a column of the union of the columns of a heterogeneous collection. Columns
+// with the same header are only merged with the column of the same occurrence, so that an element
+// type with duplicate headers keeps all of its columns.
+type unionKey struct {
+	header     string
+	occurrence int
+}
+
+// unionElements returns the indirect non-nil elements of a collection if every one of them is a
+// struct, a TableRowProvider or a map, and at least one of them is not a map.
+func unionElements(v reflect.Value) ([]reflect.Value, bool) {
+	elems := make([]reflect.Value, 0, v.Len())
+	found := false
+	for i := 0; i < v.Len(); i++ {
+		fv, ok := indirectValue(v.Index(i))
+		switch {
+		case !ok || !fv.IsValid():
+			continue
+		case fv.Kind() == reflect.Struct || isTableRowProvider(fv):
+			found = true
+		case !isMapValue(fv):
+			return nil, false
+		}
+		elems = append(elems, fv)
+	}
+	return elems, found
+}
+
+// processUnion resolves a row for every element of a heterogeneous collection (see unionElements), like a slice
+// of []any holding different types. The columns are the union of the columns of every element, in
+// order of first appearance, and elements without a column leave its cell empty. The layout of a
+// column is the layout of its first appearance.
+func processUnion(
+	elems []reflect.Value,
+	options PrintOptions,
+) (headers []string, layouts []ColumnLayout, rows [][]string) {
+	headers = []string{}
+	positions := map[unionKey]int{}
+	columns := make([][]int, 0, len(elems))
+	cells := make([][]string, 0, len(elems))
+	for _, elem := range elems {
+		elemHeaders, elemLayouts, elemCells := elementColumns(elem, options)
+		occurrences := map[string]int{}
+		elemColumns := make([]int, len(elemHeaders))
+		for i, header := range elemHeaders {
+			key := unionKey{header: header, occurrence: occurrences[header]}
+			occurrences[header]++
+			pos, ok := positions[key]
+			if !ok {
+				pos = len(headers)
+				positions[key] = pos
+				headers = append(headers, header)
+				layouts = append(layouts, elemLayouts[i])
+			}
+			elemColumns[i] = pos
+		}
+		columns = append(columns, elemColumns)
+		cells = append(cells, elemCells)
+	}
+
+	rows = make([][]string, 0, len(elems))
+	for i := range elems {
+		row := make([]string, len(headers))
+		for j, pos := range columns[i] {
+			row[pos] = cells[i][j]
+		}
+		rows = append(rows, row)
+	}
+	return headers, layouts, rows
+}
+
+// elementColumns resolves the headers, layouts and cells of a single TableRowProvider, struct or map.
+func elementColumns(
+	elem reflect.Value,
+	options PrintOptions,
+) (headers []string, layouts []ColumnLayout, cells []string) {
+	if !elem.IsValid() {
+		// nil elements have no columns
+		return nil, nil, nil
+	}
+	if headers, row, ok := resolveTableRow(elem); ok {
+		return headers, make([]ColumnLayout, len(headers)), row
+	}
+	if elem.Kind() == reflect.Struct {
+		plan := tablePlanFor(elem.Type(), options)
+		return plan.headers, plan.layouts(), plan.row(elem)
+	}
+	headers, rows := processMapCollection([]reflect.Value{elem}, options)
+	return headers, make([]ColumnLayout, len(headers)), rows[0]
+}
+
+// kindOf returns the kind of the rows resolved from a type, which is the name of the type.
+func kindOf(t reflect.Type) string {
+	return t.Name()
+}
+
+// prependColumn returns headers and rows with a column prepended to them. The cell of every row is
+// resolved by cell.
+func prependColumn(
+	header string,
+	headers []string,
+	rows [][]string,
+	cell func(row int) string,
+) ([]string, [][]string) {
+	headers = append([]string{header}, headers...)
+	rows = lo.Map(rows, func(row []string, i int) []string {
+		return append([]string{cell(i)}, row...)
+	})
+	return headers, rows
+}
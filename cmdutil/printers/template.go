@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lainio/err2"
+	"github.com/lainio/err2/try"
+	"gopkg.in/yaml.v3"
+)
+
+var _ ObjectPrinter = (*GoTemplatePrinter)(nil)
+
+// TemplateFuncs is the library of helper functions available to templates executed by a
+// GoTemplatePrinter, in addition to the text/template builtins.
+var TemplateFuncs = template.FuncMap{
+	"join":     templateJoin,
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"trim":     strings.TrimSpace,
+	"default":  templateDefault,
+	"toJson":   templateToJSON,
+	"toYaml":   templateToYAML,
+	"padLeft":  templatePadLeft,
+	"padRight": templatePadRight,
+}
+
+// GoTemplatePrinter is an implementation of ObjectPrinter which formats data with a go template
+// (see [text/template]), using the same conventions as kubectl's go-template output.
+//
+// Objects are converted to their JSON representation before the template is executed, so field
+// names in the template match the output of JSONPrinter (e.g. "{{.metadata.name}}").
+// Helper functions from TemplateFuncs are available to the template.
+type GoTemplatePrinter struct {
+	rawTemplate string
+	template    *template.Template
+}
+
+// PrintObj implements ObjectPrinter.
+func (p *GoTemplatePrinter) PrintObj(obj any, w io.Writer) (err error) {
+	defer err2.Handle(&err, func(err error) error {
+		return fmt.Errorf("error executing template %q: %w", p.rawTemplate, err)
+	})
+	try.To(p.template.Execute(w, try.To1(toJSONValue(obj))))
+	return nil
+}
+
+// AllowMissingKeys configures whether a missing field or map key is an error while executing the
+// template. When allowed, missing values print as "<no value>".
+func (p *GoTemplatePrinter) AllowMissingKeys(allow bool) {
+	if allow {
+		p.template.Option("missingkey=default")
+	} else {
+		p.template.Option("missingkey=error")
+	}
+}
+
+// NewGoTemplatePrinter parses the given go template and returns a GoTemplatePrinter for it.
+func NewGoTemplatePrinter(tmpl string) (_ *GoTemplatePrinter, err error) {
+	defer err2.Handle(&err, func(err error) error {
+		return fmt.Errorf("error parsing template %q: %w", tmpl, err)
+	})
+	t := try.To1(template.New("output").Funcs(TemplateFuncs).Parse(tmpl))
+	return &GoTemplatePrinter{
+		rawTemplate: tmpl,
+		template:    t,
+	}, nil
+}
+
+// templateJoin joins the elements of a list with sep, e.g. {{ .items | join ", " }}.
+func templateJoin(sep string, list any) string {
+	v, ok := indirectValue(reflect.ValueOf(list))
+	if !ok {
+		return ""
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Sprint(v.Interface())
+	}
+	items := make([]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		items = append(items, fmt.Sprint(v.Index(i).Interface()))
+	}
+	return strings.Join(items, sep)
+}
+
+// templateDefault returns def if the given value is missing or empty, e.g.
+// {{ .description | default "none" }}.
+func templateDefault(def any, given ...any) any {
+	if len(given) == 0 || isEmptyTemplateValue(given[0]) {
+		return def
+	}
+	return given[0]
+}
+
+func isEmptyTemplateValue(given any) bool {
+	v, ok := indirectValue(reflect.ValueOf(given))
+	if !ok || !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+func templateToJSON(v any) (string, error) {
+	bts, err := json.Marshal(v)
+	return string(bts), err
+}
+
+func templateToYAML(v any) (string, error) {
+	buf := &bytes.Buffer{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(YAMLIndentLevel)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// templatePadLeft right-aligns a value in a column of the given width, e.g.
+// {{ .count | padLeft 6 }}.
+func templatePadLeft(width int, v any) string {
+	s := fmt.Sprint(v)
+	return strings.Repeat(" ", max(width-lipgloss.Width(s), 0)) + s
+}
+
+// templatePadRight left-aligns a value in a column of the given width, e.g.
+// {{ .name | padRight 20 }}.
+func templatePadRight(width int, v any) string {
+	s := fmt.Sprint(v)
+	return s + strings.Repeat(" ", max(width-lipgloss.Width(s), 0))
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GoTemplatePrinter", Label("unit"), func() {
+	type Item struct {
+		Name        string   `json:"name"`
+		Description string   `json:"description,omitempty"`
+		Tags        []string `json:"tags"`
+	}
+
+	var (
+		items  []Item
+		buffer *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		items = []Item{
+			{Name: "foo", Description: "the foo", Tags: []string{"a", "b"}},
+			{Name: "bar", Tags: []string{}},
+		}
+		buffer = &bytes.Buffer{}
+	})
+
+	render := func(tmpl string, obj any) string {
+		printer, err := printers.NewGoTemplatePrinter(tmpl)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(printer.PrintObj(obj, buffer)).To(Succeed())
+		return buffer.String()
+	}
+
+	Context("when parsing a template", func() {
+		It("should return an error for an invalid template", func() {
+			_, err := printers.NewGoTemplatePrinter("{{ .name ")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("when printing an object", func() {
+		It("should resolve fields using their json names", func() {
+			Expect(render("{{ .name }}", items[0])).To(Equal("foo"))
+		})
+
+		It("should range over slices", func() {
+			Expect(render("{{ range . }}{{ .name }};{{ end }}", items)).To(Equal("foo;bar;"))
+		})
+
+		It("should print large integers without an exponent", func() {
+			obj := struct {
+				Size int64 `json:"size"`
+			}{Size: 12345678}
+			Expect(render("{{ .size }}", obj)).To(Equal("12345678"))
+		})
+
+		It("should return an error for a missing key unless missing keys are allowed", func() {
+			printer, err := printers.NewGoTemplatePrinter("{{ .missing }}")
+			Expect(err).NotTo(HaveOccurred())
+			printer.AllowMissingKeys(false)
+			Expect(printer.PrintObj(items[0], buffer)).NotTo(Succeed())
+
+			buffer.Reset()
+			printer.AllowMissingKeys(true)
+			Expect(printer.PrintObj(items[0], buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("<no value>"))
+		})
+
+		It("should return an error when the object cannot be marshalled", func() {
+			printer, err := printers.NewGoTemplatePrinter("{{ .name }}")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(printer.PrintObj(func() {}, buffer)).NotTo(Succeed())
+		})
+	})
+
+	DescribeTable("helper functions",
+		func(tmpl string, expected string) {
+			Expect(render(tmpl, items[0])).To(Equal(expected))
+		},
+		Entry("join", `{{ .tags | join "," }}`, "a,b"),
+		Entry("upper", `{{ .name | upper }}`, "FOO"),
+		Entry("lower", `{{ "FOO" | lower }}`, "foo"),
+		Entry("trim", `{{ " foo " | trim }}`, "foo"),
+		Entry("default with a value", `{{ .description | default "none" }}`, "the foo"),
+		Entry("default with an empty value", `{{ "" | default "none" }}`, "none"),
+		Entry("toJson", `{{ .tags | toJson }}`, `["a","b"]`),
+		Entry("toYaml", `{{ .tags | toYaml }}`, "- a\n- b"),
+		Entry("padLeft", `[{{ .name | padLeft 5 }}]`, "[  foo]"),
+		Entry("padRight", `[{{ .name | padRight 5 }}]`, "[foo  ]"),
+	)
+})
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"golang.org/x/term"
+)
+
+// terminalWidth returns the width of the terminal that w writes to, falling back to the $COLUMNS
+// environment variable when the size of the terminal cannot be queried. It returns false if the
+// width is unknown, like when w is not a terminal: output that is piped, redirected or written to
+// a buffer is never fit to a width, even when $COLUMNS is set.
+func terminalWidth(w io.Writer) (int, bool) {
+	f, ok := w.(interface{ Fd() uintptr })
+	if !ok {
+		return 0, false
+	}
+	fd := int(f.Fd()) //nolint:gosec // file descriptors fit in an int
+	if !term.IsTerminal(fd) {
+		return 0, false
+	}
+	if width, _, err := term.GetSize(fd); err == nil && width > 0 {
+		return width, true
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns, true
+	}
+	return 0, false
+}
+
+// displayWidth returns the number of terminal columns needed to display s, which is the width of
+// its widest line. Widths are measured per grapheme cluster, so East Asian wide characters and
+// emoji count as two columns and combining marks as none, and ANSI escape sequences are ignored.
+// Printers that align text in columns must measure cells with displayWidth rather than len.
+func displayWidth(s string) int {
+	width := 0
+	for _, line := range strings.Split(s, "\n") {
+		width = max(width, ansi.StringWidth(line))
+	}
+	return width
+}
@@ -0,0 +1,418 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// TimeLayoutRFC3339 prints times in RFC 3339 format, like "2006-01-02T15:04:05Z07:00".
+	TimeLayoutRFC3339 = "rfc3339"
+	// TimeLayoutUnix prints times as the number of seconds since the Unix epoch. JSON and YAML
+	// output print them as numbers.
+	TimeLayoutUnix = "unix"
+	// TimeLayoutRelative prints times relative to the current time, like "5m ago" or "in 2d".
+	TimeLayoutRelative = "relative"
+)
+
+// TimeLayouts are the named layouts of a TimeFormat. Any other layout is a layout for
+// [time.Time.Format].
+var TimeLayouts = []string{TimeLayoutRFC3339, TimeLayoutUnix, TimeLayoutRelative}
+
+// timeType is the type of time.Time values, which are formatted by TimeFormat.
+var timeType = reflect.TypeOf(time.Time{})
+
+// TimeFormat configures how printers print time.Time values, so that output printed on machines
+// in different time zones can be compared. The zero TimeFormat prints times as usual.
+type TimeFormat struct {
+	// Location is the time zone that times are printed in, or nil to print times in their own
+	// location.
+	Location *time.Location
+	// Layout is one of the TimeLayouts or a layout for [time.Time.Format], or empty to print times
+	// in the default format of the printer.
+	Layout string
+}
+
+// ParseTimeFormat returns the TimeFormat of a time zone name, like "UTC", "Local" or
+// "Europe/Paris" (see [time.LoadLocation]), and a layout. Either may be empty.
+func ParseTimeFormat(timezone, layout string) (TimeFormat, error) {
+	format := TimeFormat{Layout: layout}
+	if timezone != "" {
+		location, err := loadLocation(timezone)
+		if err != nil {
+			return TimeFormat{}, fmt.Errorf("invalid time zone %q: %w", timezone, err)
+		}
+		format.Location = location
+	}
+	return format, nil
+}
+
+// locations caches the time zones loaded by ParseTimeFormat by name. time.LoadLocation returns a
+// new *time.Location on every call, and table plans are cached per location (see tablePlanKey).
+var locations = struct {
+	sync.Mutex
+	byName map[string]*time.Location
+}{byName: map[string]*time.Location{}}
+
+// loadLocation is like time.LoadLocation, but returns the same *time.Location for every call with
+// the same name. It is safe for concurrent use.
+func loadLocation(name string) (*time.Location, error) {
+	locations.Lock()
+	defer locations.Unlock()
+	if location, ok := locations.byName[name]; ok {
+		return location, nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.byName[name] = location
+	return location, nil
+}
+
+// IsZero reports whether f is the zero TimeFormat, which prints times as usual.
+func (f TimeFormat) IsZero() bool {
+	return f.Location == nil && f.Layout == ""
+}
+
+// Format returns t in the time zone and layout of f. Times are printed like [time.Time.String] if
+// the layout is empty.
+func (f TimeFormat) Format(t time.Time) string {
+	switch value := f.value(t).(type) {
+	case time.Time:
+		return value.String()
+	case int64:
+		return strconv.FormatInt(value, 10)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// value returns t in the time zone and layout of f: a time.Time if the layout is empty, an int64
+// for TimeLayoutUnix, and a string for any other layout.
+func (f TimeFormat) value(t time.Time) any {
+	t = f.in(t)
+	switch f.Layout {
+	case "":
+		return t
+	case TimeLayoutRFC3339:
+		return t.Format(time.RFC3339)
+	case TimeLayoutUnix:
+		return t.Unix()
+	case TimeLayoutRelative:
+		if t.IsZero() {
+			return "<unknown>"
+		}
+		d := nowFunc().Sub(t)
+		if d < 0 {
+			return "in " + humanDuration(-d)
+		}
+		return humanDuration(d) + " ago"
+	default:
+		return t.Format(f.Layout)
+	}
+}
+
+// cellFormatter returns a cellFormatter for time.Time fields that formats them in the time zone and
+// layout of f. If the field has its own format, like a "format" header tag option, times are only
+// converted to the time zone of f before they are formatted with format.
+func (f TimeFormat) cellFormatter(format cellFormatter, ownFormat bool) cellFormatter {
+	if f.IsZero() {
+		return format
+	}
+	return func(v reflect.Value) string {
+		if !v.CanInterface() {
+			return format(v)
+		}
+		t, ok := v.Interface().(time.Time)
+		switch {
+		case !ok:
+			return format(v)
+		case ownFormat || f.Layout == "":
+			return format(reflect.ValueOf(f.in(t)))
+		default:
+			return f.Format(t)
+		}
+	}
+}
+
+// in returns t in the time zone of f.
+func (f TimeFormat) in(t time.Time) time.Time {
+	if f.Location != nil {
+		return t.In(f.Location)
+	}
+	return t
+}
+
+// encodeJSON encodes obj with enc, with every time.Time value formatted by f. Times are found by
+// reflection, so strings that merely look like times are printed as-is.
+func (f TimeFormat) encodeJSON(enc *json.Encoder, obj any) error {
+	if f.IsZero() {
+		return enc.Encode(obj)
+	}
+	return enc.Encode(f.jsonValue(reflect.ValueOf(obj), map[uintptr]bool{}))
+}
+
+var (
+	anyType           = reflect.TypeFor[any]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+)
+
+// jsonValue returns a value that encodes to the same JSON as v, except that every time.Time is
+// replaced with its value in f (see TimeFormat.value). Values that can't hold a time.Time are
+// returned as-is, so they are encoded exactly like encoding/json would.
+//
+// Structs that hold times are mirrored with reflect.StructOf, keeping the names and tags of their
+// fields, so that encoding/json resolves the same keys. visiting holds the pointers being
+// converted, so that cycles are left to encoding/json to report.
+func (f TimeFormat) jsonValue(v reflect.Value, visiting map[uintptr]bool) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Type() == timeType {
+		return f.value(v.Interface().(time.Time)) //nolint:forcetypeassert // checked by the type
+	}
+	if !mayHoldTime(v.Type(), map[reflect.Type]bool{}) {
+		return interfaceOf(v)
+	}
+	switch v.Kind() { //nolint:exhaustive // mayHoldTime is false for every other kind
+	case reflect.Ptr:
+		if v.IsNil() || visiting[v.Pointer()] {
+			return v.Interface()
+		}
+		visiting[v.Pointer()] = true
+		defer delete(visiting, v.Pointer())
+		return f.jsonValue(v.Elem(), visiting)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return f.jsonValue(v.Elem(), visiting)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		array := make([]any, v.Len())
+		for i := range array {
+			array[i] = f.jsonValue(v.Index(i), visiting)
+		}
+		return array
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		m := reflect.MakeMapWithSize(reflect.MapOf(v.Type().Key(), anyType), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			value := f.jsonValue(iter.Value(), visiting)
+			m.SetMapIndex(iter.Key(), reflect.ValueOf(&value).Elem())
+		}
+		return m.Interface()
+	case reflect.Struct:
+		mirror := f.jsonStruct(v, visiting)
+		if v.CanAddr() {
+			return mirror.Addr().Interface()
+		}
+		return mirror.Interface()
+	default:
+		return interfaceOf(v)
+	}
+}
+
+// jsonStruct returns an addressable mirror of the struct v for jsonValue. Fields that can't hold
+// a time.Time keep their type and value. Fields that can are converted with jsonValue into fields
+// of type any, and since those are only empty when they are nil, they are left out here when the
+// "omitempty" or "omitzero" tag options apply. Embedded structs are mirrored too, so that
+// encoding/json still promotes their fields.
+func (f TimeFormat) jsonStruct(v reflect.Value, visiting map[uintptr]bool) reflect.Value {
+	if !v.CanAddr() {
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		v = c
+	}
+	var (
+		fields []reflect.StructField
+		values []reflect.Value
+	)
+	for i := 0; i < v.NumField(); i++ {
+		field, fv := v.Type().Field(i), v.Field(i)
+		if !field.IsExported() {
+			if !field.Anonymous || indirectType(field.Type).Kind() != reflect.Struct {
+				continue
+			}
+			// encoding/json promotes the fields of unexported embedded structs, but reflection
+			// doesn't allow reading them, and StructOf doesn't allow unexported fields
+			fv = reflect.NewAt(field.Type, unsafe.Pointer(fv.UnsafeAddr())).Elem() //nolint:gosec // fv is addressable
+			field.Name = fmt.Sprintf("Embedded%d_", i)
+		}
+		tag := field.Tag.Get("json")
+		name, _, _ := strings.Cut(tag, ",")
+		switch {
+		case tag == "-":
+			continue
+		case field.Anonymous && name == "" && indirectType(field.Type).Kind() == reflect.Struct:
+			if ev, ok := indirectValue(fv); ok {
+				mirror := f.jsonStruct(ev, visiting)
+				embedded := mirrorField(field, mirror.Type())
+				embedded.Anonymous = true
+				fields, values = append(fields, embedded), append(values, mirror)
+			}
+		case !mayHoldTime(field.Type, map[reflect.Type]bool{}):
+			fields, values = append(fields, mirrorField(field, field.Type)), append(values, fv)
+		case isOmittedJSONField(field, fv):
+			continue
+		default:
+			value := f.jsonValue(fv, visiting)
+			fields = append(fields, mirrorField(field, anyType))
+			values = append(values, reflect.ValueOf(&value).Elem())
+		}
+	}
+	mirror := reflect.New(reflect.StructOf(fields)).Elem()
+	for i, value := range values {
+		mirror.Field(i).Set(value)
+	}
+	return mirror
+}
+
+// mirrorField returns a field of type typ with the name and tag of field. It is not embedded, since
+// StructOf doesn't support embedding types with methods, but encoding/json encodes fields that
+// are embedded without being promoted like any other field of the same name.
+func mirrorField(field reflect.StructField, typ reflect.Type) reflect.StructField {
+	return reflect.StructField{Name: field.Name, Type: typ, Tag: field.Tag}
+}
+
+// isOmittedJSONField reports whether encoding/json leaves out the value v of field, because of its
+// "omitempty" or "omitzero" tag options.
+func isOmittedJSONField(field reflect.StructField, v reflect.Value) bool {
+	_, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+	opts = "," + opts + ","
+	if strings.Contains(opts, ",omitzero,") {
+		if z, ok := v.Interface().(interface{ IsZero() bool }); ok {
+			if v.Kind() != reflect.Ptr || !v.IsNil() {
+				return z.IsZero()
+			}
+		}
+		if v.IsZero() {
+			return true
+		}
+	}
+	if !strings.Contains(opts, ",omitempty,") {
+		return false
+	}
+	switch v.Kind() { //nolint:exhaustive // other kinds are never empty
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// mayHoldTime reports whether values of type t may hold a time.Time that encoding/json encodes
+// through its default encoding, rather than through a json.Marshaler or encoding.TextMarshaler.
+func mayHoldTime(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if t == timeType || t.Kind() == reflect.Interface {
+		return true
+	}
+	if t.Kind() == reflect.Ptr {
+		// a pointer shares the methods of its element, which are checked there
+		return mayHoldTime(t.Elem(), visiting)
+	}
+	if visiting[t] || t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) ||
+		reflect.PointerTo(t).Implements(jsonMarshalerType) ||
+		reflect.PointerTo(t).Implements(textMarshalerType) {
+		return false
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+	switch t.Kind() { //nolint:exhaustive // other kinds can't hold a time.Time
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return mayHoldTime(t.Elem(), visiting)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if (f.IsExported() || f.Anonymous) && mayHoldTime(f.Type, visiting) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// interfaceOf returns the value of v, or a pointer to it if v is addressable, so that encoding/json
+// still calls methods with a pointer receiver on it.
+func interfaceOf(v reflect.Value) any {
+	if v.CanAddr() {
+		return v.Addr().Interface()
+	}
+	if !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// encodeYAML encodes obj with enc, with every time formatted by f.
+func (f TimeFormat) encodeYAML(enc *yaml.Encoder, obj any) error {
+	if f.IsZero() {
+		return enc.Encode(obj)
+	}
+	var node yaml.Node
+	if err := node.Encode(obj); err != nil {
+		return err
+	}
+	f.rewriteYAML(&node)
+	return enc.Encode(&node)
+}
+
+// rewriteYAML formats every timestamp of a YAML node and its children with f.
+func (f TimeFormat) rewriteYAML(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!timestamp" {
+		var t time.Time
+		if err := node.Decode(&t); err == nil {
+			switch value := f.value(t).(type) {
+			case time.Time:
+				node.Value = value.Format(time.RFC3339Nano)
+			case int64:
+				node.Tag = "!!int"
+				node.Value = strconv.FormatInt(value, 10)
+			default:
+				node.Tag = "!!str"
+				node.Value = fmt.Sprint(value)
+			}
+		}
+	}
+	for _, child := range node.Content {
+		f.rewriteYAML(child)
+	}
+}
@@ -0,0 +1,288 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+	_ "time/tzdata" // for time zones like Europe/Paris on machines without a zoneinfo database
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TimeFormat", Label("unit"), func() {
+	type event struct {
+		Name    string    `json:"name"    yaml:"name"`
+		Created time.Time `json:"created" yaml:"created"`
+		Updated time.Time `json:"updated" yaml:"updated" header:"UPDATED,format=time:2006-01-02 15:04"`
+		Note    string    `json:"note"    yaml:"note"`
+	}
+
+	paris := time.FixedZone("CEST", 2*60*60)
+	e := event{
+		Name:    "deploy",
+		Created: time.Date(2024, 5, 6, 9, 8, 9, 0, paris),
+		Updated: time.Date(2024, 5, 6, 10, 0, 0, 0, paris),
+		Note:    "not a time",
+	}
+
+	printEvent := func(printer printers.ObjectPrinter) string {
+		var buf bytes.Buffer
+		Expect(printer.PrintObj(e, &buf)).To(Succeed())
+		return buf.String()
+	}
+
+	DescribeTable("table cells",
+		func(layout string, expected []string) {
+			format := lo.Must(printers.ParseTimeFormat("UTC", layout))
+			headers, rows, err := printers.NewTableReflectorFunc(printers.PrintOptions{TimeFormat: format})(e)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(headers).To(Equal([]string{"NAME", "CREATED", "UPDATED", "NOTE"}))
+			Expect(rows).To(Equal([][]string{expected}))
+		},
+		Entry("in the time zone only", "",
+			[]string{"deploy", "2024-05-06 07:08:09 +0000 UTC", "2024-05-06 08:00", "not a time"}),
+		Entry("in RFC 3339 format", printers.TimeLayoutRFC3339,
+			[]string{"deploy", "2024-05-06T07:08:09Z", "2024-05-06 08:00", "not a time"}),
+		Entry("as Unix timestamps", printers.TimeLayoutUnix,
+			[]string{"deploy", "1714979289", "2024-05-06 08:00", "not a time"}),
+		Entry("in a custom layout", "Jan 2 15:04",
+			[]string{"deploy", "May 6 07:08", "2024-05-06 08:00", "not a time"}),
+	)
+
+	Context("when printing relative times", func() {
+		now := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+		BeforeEach(func() {
+			DeferCleanup(printers.SetNow(now))
+		})
+
+		DescribeTable("the relative layout",
+			func(value time.Time, expected string) {
+				format := printers.TimeFormat{Layout: printers.TimeLayoutRelative}
+				Expect(format.Format(value)).To(Equal(expected))
+			},
+			Entry("in the past", now.Add(-5*time.Minute), "5m ago"),
+			Entry("in the future", now.Add(50*time.Hour), "in 2d2h"),
+			Entry("in hours", now.Add(-26*time.Hour), "26h ago"),
+			Entry("of a zero time", time.Time{}, "<unknown>"),
+		)
+	})
+
+	It("should reuse table plans for time zones parsed more than once", func() {
+		options := func() printers.PrintOptions {
+			return printers.PrintOptions{TimeFormat: lo.Must(printers.ParseTimeFormat("Europe/Paris", ""))}
+		}
+		Expect(printers.CachedTablePlan(e, options())).To(BeIdenticalTo(printers.CachedTablePlan(e, options())))
+		Expect(printers.CachedTablePlan(e, options())).
+			NotTo(BeIdenticalTo(printers.CachedTablePlan(e, printers.PrintOptions{})))
+	})
+
+	DescribeTable("time zones that share a name",
+		func(first, second *time.Location, expected string) {
+			created := func(location *time.Location) string {
+				options := printers.PrintOptions{TimeFormat: printers.TimeFormat{Location: location}}
+				_, rows, err := printers.NewTableReflectorFunc(options)(e)
+				Expect(err).NotTo(HaveOccurred())
+				return rows[0][1]
+			}
+			created(first)
+			Expect(created(second)).To(Equal(expected))
+		},
+		Entry("an unnamed zone after no zone",
+			nil, time.FixedZone("", 2*60*60), "2024-05-06 09:08:09 +0200 +0200"),
+		Entry("zones with the same name and different offsets",
+			time.FixedZone("X", 60*60), time.FixedZone("X", 5*60*60), "2024-05-06 12:08:09 +0500 X"),
+	)
+
+	DescribeTable("table cells of times that are only known from the value",
+		func(obj any, expectedHeaders []string, expectedRows [][]string) {
+			format := lo.Must(printers.ParseTimeFormat("UTC", printers.TimeLayoutUnix))
+			headers, rows, err := printers.NewTableReflectorFunc(printers.PrintOptions{TimeFormat: format})(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(headers).To(Equal(expectedHeaders))
+			Expect(rows).To(Equal(expectedRows))
+		},
+		Entry("in interface fields", struct {
+			Name string
+			At   any
+		}{"deploy", e.Created}, []string{"NAME", "AT"}, [][]string{{"deploy", "1714979289"}}),
+		Entry("in maps", map[string]time.Time{"created": e.Created},
+			[]string{"KEY", "VALUE"}, [][]string{{"created", "1714979289"}}),
+		Entry("in collections of maps", []map[string]any{{"name": "deploy", "created": &e.Created}},
+			[]string{"CREATED", "NAME"}, [][]string{{"1714979289", "deploy"}}),
+	)
+
+	It("should format times in maps of described objects", func() {
+		format := lo.Must(printers.ParseTimeFormat("UTC", printers.TimeLayoutUnix))
+		var buf bytes.Buffer
+		Expect(printers.NewDescribePrinter(printers.PrintOptions{TimeFormat: format}).PrintObj(struct {
+			Labels map[string]any
+		}{map[string]any{"created": e.Created}}, &buf)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("1714979289"))
+		Expect(buf.String()).NotTo(ContainSubstring("2024"))
+	})
+
+	It("should format times in CSV output", func() {
+		format := lo.Must(printers.ParseTimeFormat("UTC", printers.TimeLayoutUnix))
+		Expect(printEvent(printers.NewCSVPrinter(printers.PrintOptions{TimeFormat: format}))).To(Equal(
+			"NAME,CREATED,UPDATED,NOTE\ndeploy,1714979289,2024-05-06 08:00,not a time\n",
+		))
+	})
+
+	It("should format times in JSON output", func() {
+		format := lo.Must(printers.ParseTimeFormat("UTC", printers.TimeLayoutUnix))
+		Expect(printEvent(&printers.JSONPrinter{TimeFormat: format})).To(MatchJSON(
+			`{"name":"deploy","created":1714979289,"updated":1714982400,"note":"not a time"}`,
+		))
+	})
+
+	Context("when printing JSON", func() {
+		format := lo.Must(printers.ParseTimeFormat("UTC", printers.TimeLayoutUnix))
+
+		printJSON := func(obj any) string {
+			var buf bytes.Buffer
+			Expect((&printers.JSONPrinter{TimeFormat: format}).PrintObj(obj, &buf)).To(Succeed())
+			return buf.String()
+		}
+
+		It("should not format strings that look like times", func() {
+			Expect(printJSON(struct{ Version string }{"2024-01-02T03:04:05Z"})).
+				To(Equal(`{"Version":"2024-01-02T03:04:05Z"}` + "\n"))
+		})
+
+		It("should format times in embedded structs, pointers, slices, maps and interfaces", func() {
+			type meta struct {
+				Created time.Time `json:"created"`
+			}
+			type Labels struct {
+				Owner string `json:"owner,omitempty"`
+			}
+			created := time.Unix(1714979289, 0)
+			Expect(printJSON(&struct {
+				meta
+				Labels
+				Name     string               `json:"name"`
+				Deleted  *time.Time           `json:"deleted,omitempty"`
+				Updated  *time.Time           `json:"updated"`
+				History  []time.Time          `json:"history"`
+				Events   map[string]time.Time `json:"events"`
+				Extra    any                  `json:"extra"`
+				Ignored  time.Time            `json:"-"`
+				internal time.Time
+			}{
+				meta:     meta{Created: created},
+				Labels:   Labels{Owner: "infra"},
+				Name:     "2024-01-02T03:04:05Z",
+				Updated:  &created,
+				History:  []time.Time{created},
+				Events:   map[string]time.Time{"started": created},
+				Extra:    map[string]any{"at": created},
+				Ignored:  created,
+				internal: created,
+			})).To(Equal(`{"created":1714979289,"owner":"infra","name":"2024-01-02T03:04:05Z",` +
+				`"updated":1714979289,"history":[1714979289],"events":{"started":1714979289},` +
+				`"extra":{"at":1714979289}}` + "\n"))
+		})
+
+		It("should keep the encoding of types that marshal themselves", func() {
+			Expect(printJSON(map[string]json.RawMessage{"raw": json.RawMessage(`"2024-01-02T03:04:05Z"`)})).
+				To(Equal(`{"raw":"2024-01-02T03:04:05Z"}` + "\n"))
+		})
+	})
+
+	It("should convert times to the time zone in JSON lines output", func() {
+		format := lo.Must(printers.ParseTimeFormat("UTC", ""))
+		Expect(printEvent(&printers.JSONLinesPrinter{TimeFormat: format})).To(Equal(
+			`{"name":"deploy","created":"2024-05-06T07:08:09Z","updated":"2024-05-06T08:00:00Z","note":"not a time"}` +
+				"\n",
+		))
+	})
+
+	It("should format times in YAML output", func() {
+		format := lo.Must(printers.ParseTimeFormat("UTC", printers.TimeLayoutRFC3339))
+		Expect(printEvent(&printers.YamlPrinter{TimeFormat: format})).To(Equal(
+			"name: deploy\ncreated: \"2024-05-06T07:08:09Z\"\nupdated: \"2024-05-06T08:00:00Z\"\nnote: not a time\n",
+		))
+	})
+
+	It("should print times as numbers in YAML output", func() {
+		format := lo.Must(printers.ParseTimeFormat("", printers.TimeLayoutUnix))
+		Expect(printEvent(&printers.YamlPrinter{TimeFormat: format})).To(ContainSubstring("created: 1714979289\n"))
+	})
+
+	It("should return an error for an invalid time zone", func() {
+		_, err := printers.ParseTimeFormat("Mars/Olympus_Mons", "")
+		Expect(err).To(MatchError(ContainSubstring(`invalid time zone "Mars/Olympus_Mons"`)))
+	})
+
+	Describe("TimeFormatFlags", func() {
+		It("should add the timezone and time-format flags once", func() {
+			flags := &printers.TimeFormatFlags{Timezone: lo.ToPtr(""), TimeFormat: lo.ToPtr("")}
+			cmd := &cobra.Command{}
+			flags.AddFlags(cmd)
+			flags.AddFlags(cmd)
+			Expect(cmd.Flag("timezone")).NotTo(BeNil())
+			Expect(cmd.Flag("time-format")).NotTo(BeNil())
+		})
+
+		It("should return the time format of the flags", func() {
+			flags := &printers.TimeFormatFlags{Timezone: lo.ToPtr("UTC"), TimeFormat: lo.ToPtr("unix")}
+			Expect(flags.ToTimeFormat()).To(Equal(printers.TimeFormat{Location: time.UTC, Layout: "unix"}))
+		})
+
+		It("should apply to every output format of PrintFlags", func() {
+			printFlags := printers.NewPrintFlags()
+			printFlags.RegisteredPrintFlaggers = append(printFlags.RegisteredPrintFlaggers,
+				&printers.TableCSVPrinterFlags{TimeFormat: printFlags.TimeFormat})
+			cmd := &cobra.Command{}
+			printFlags.AddFlags(cmd)
+			Expect(cmd.ParseFlags([]string{"-o", "csv", "--timezone", "UTC", "--time-format", "unix"})).To(Succeed())
+
+			printer, err := printFlags.ToPrinter()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(printEvent(printer)).To(ContainSubstring("deploy,1714979289,"))
+
+			*printFlags.OutputFormat = "json"
+			printer, err = printFlags.ToPrinter()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(printEvent(printer)).To(ContainSubstring(`"created":1714979289`))
+		})
+
+		It("should return an error for an invalid time zone", func() {
+			flags := &printers.TableCSVPrinterFlags{
+				TimeFormat: &printers.TimeFormatFlags{Timezone: lo.ToPtr("Nowhere")},
+			}
+			printer, err := flags.ToPrinter("table")
+			Expect(err).To(MatchError(ContainSubstring("invalid time zone")))
+			Expect(printers.IsNoCompatiblePrinterError(err)).To(BeFalse())
+			Expect(printer).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"encoding"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+var _ ObjectPrinter = (*TOMLPrinter)(nil)
+
+// TOMLPrinter prints objects as TOML documents.
+//
+// Struct fields are named after their "toml" tag, falling back to their "json" tag and then to
+// the field name, and honor the "omitempty" tag option. Fields of embedded structs without a name
+// are promoted to the parent table. Since TOML has no null value, nil fields and nil array
+// elements are omitted, and the top-level object must encode to a table (such as a struct or a
+// map).
+type TOMLPrinter struct{}
+
+// PrintObj implements ObjectPrinter.
+func (p *TOMLPrinter) PrintObj(obj any, w io.Writer) error {
+	value := toTOMLValue(reflect.ValueOf(obj))
+	switch value.(type) {
+	case map[string]any, toml.Marshaler:
+		return toml.NewEncoder(w).Encode(value)
+	default:
+		return fmt.Errorf("cannot print %T as TOML: the top-level value must be a table", obj)
+	}
+}
+
+var (
+	tomlMarshalerType = reflect.TypeFor[toml.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// toTOMLValue converts v into a tree of maps, slices and values that the TOML encoder knows how to
+// print, resolving struct field names from their tags. Types that marshal themselves are returned
+// as-is.
+func toTOMLValue(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Type().Implements(tomlMarshalerType) || v.Type().Implements(textMarshalerType) {
+		if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+			return nil
+		}
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return toTOMLValue(v.Elem())
+	case reflect.Struct:
+		table := make(map[string]any)
+		addTOMLFields(table, v)
+		return table
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		table := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			if value := toTOMLValue(iter.Value()); value != nil {
+				table[fmt.Sprint(iter.Key().Interface())] = value
+			}
+		}
+		return table
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		array := make([]any, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if value := toTOMLValue(v.Index(i)); value != nil {
+				array = append(array, value)
+			}
+		}
+		return array
+	case reflect.Invalid, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr, reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128,
+		reflect.Chan, reflect.Func, reflect.String, reflect.UnsafePointer:
+		fallthrough
+	default:
+		return v.Interface()
+	}
+}
+
+// addTOMLFields adds the fields of the struct v to table. Fields that are already present in
+// table take precedence over fields promoted from embedded structs.
+func addTOMLFields(table map[string]any, v reflect.Value) {
+	var embedded []reflect.Value
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		name, omitEmpty := resolveTOMLKey(f)
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			if fv, ok := indirectValue(v.Field(i)); ok && fv.Kind() == reflect.Struct {
+				embedded = append(embedded, fv)
+			}
+			continue
+		}
+		if !f.IsExported() || (omitEmpty && v.Field(i).IsZero()) {
+			continue
+		}
+		if value := toTOMLValue(v.Field(i)); value != nil {
+			table[name] = value
+		}
+	}
+	for _, fv := range embedded {
+		promoted := make(map[string]any)
+		addTOMLFields(promoted, fv)
+		for name, value := range promoted {
+			if _, ok := table[name]; !ok {
+				table[name] = value
+			}
+		}
+	}
+}
+
+// resolveTOMLKey returns the key of a struct field from its "toml" tag, falling back to its
+// "json" tag and then to the field name. An empty key is returned for embedded structs without a
+// name in their tags, whose fields should be promoted to the parent table.
+func resolveTOMLKey(f reflect.StructField) (name string, omitEmpty bool) {
+	tag, ok := f.Tag.Lookup("toml")
+	if !ok {
+		tag = f.Tag.Get("json")
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	omitEmpty = strings.Contains(","+opts+",", ",omitempty,")
+	if name == "" && !(f.Anonymous && indirectType(f.Type).Kind() == reflect.Struct) {
+		name = f.Name
+	}
+	return name, omitEmpty
+}
+
+func NewTOMLPrinter() ObjectPrinter {
+	return &TOMLPrinter{}
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TOMLPrinter", Label("unit"), func() {
+	type Metadata struct {
+		Labels map[string]string `json:"labels,omitempty"`
+	}
+	type Server struct {
+		Host string `toml:"host"`
+		Port int    `json:"port"`
+	}
+	type Config struct {
+		Metadata
+		Name     string `toml:"name" json:"displayName"`
+		Title    string `json:"title,omitempty"`
+		Secret   string `toml:"-"`
+		Enabled  bool
+		Created  time.Time `toml:"created"`
+		Servers  []Server  `json:"servers"`
+		Parent   *Config   `json:"parent"`
+		internal string
+	}
+
+	var (
+		printer printers.ObjectPrinter
+		buffer  *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		printer = printers.NewTOMLPrinter()
+		buffer = new(bytes.Buffer)
+	})
+
+	It("should honor toml tags with fallback to json tags and field names", func() {
+		err := printer.PrintObj(Config{
+			Metadata: Metadata{Labels: map[string]string{"team": "infra"}},
+			Name:     "app",
+			Secret:   "hunter2",
+			Enabled:  true,
+			Created:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Servers:  []Server{{Host: "a.example.com", Port: 80}},
+			internal: "hidden",
+		}, buffer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buffer.String()).To(Equal(`Enabled = true
+created = 2024-01-02T03:04:05Z
+name = "app"
+
+[labels]
+  team = "infra"
+
+[[servers]]
+  host = "a.example.com"
+  port = 80
+`))
+	})
+
+	It("should omit nil array elements", func() {
+		err := printer.PrintObj(struct {
+			Servers []*Server `toml:"servers"`
+		}{Servers: []*Server{nil, {Host: "a.example.com"}}}, buffer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buffer.String()).To(Equal("[[servers]]\n  host = \"a.example.com\"\n  port = 0\n"))
+	})
+
+	It("should print maps as tables", func() {
+		err := printer.PrintObj(map[string]any{"key": "value", "nested": map[string]int{"a": 1}}, buffer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buffer.String()).To(Equal("key = \"value\"\n\n[nested]\n  a = 1\n"))
+	})
+
+	It("should return an error when the object is not a table", func() {
+		Expect(printer.PrintObj([]string{"a"}, buffer)).
+			To(MatchError("cannot print []string as TOML: the top-level value must be a table"))
+		Expect(buffer.String()).To(BeEmpty())
+	})
+})
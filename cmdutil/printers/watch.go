@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers
+
+import (
+	"io"
+	"sync"
+)
+
+var (
+	_ ObjectPrinter = (*WatchPrinter)(nil)
+	_ io.Closer     = (*WatchPrinter)(nil)
+)
+
+// WatchPrinter wraps an ObjectPrinter so that repeated calls to PrintObj append to the same
+// output, similar to "kubectl get --watch".
+//
+// If the wrapped printer is a [StreamingPrinter], every call to PrintObj writes to a single
+// [ObjectStream] and flushes it: headers are printed once, and table columns keep the widths
+// of the first call, widening when a later row has a wider cell rather than truncating it.
+// Otherwise, every call is passed to the wrapped printer as-is.
+//
+// Close should be called once watching is done, so that the stream can print anything needed to
+// terminate the output, such as the bottom border of a table.
+type WatchPrinter struct {
+	Printer ObjectPrinter
+
+	mu     sync.Mutex
+	stream ObjectStream
+	w      io.Writer
+}
+
+// PrintObj implements ObjectPrinter. Calling PrintObj with a different [io.Writer] than the
+// previous call closes the current stream and starts a new one.
+func (p *WatchPrinter) PrintObj(obj any, w io.Writer) error {
+	sp, ok := p.Printer.(StreamingPrinter)
+	if !ok {
+		return p.Printer.PrintObj(obj, w)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stream == nil || p.w != w {
+		if err := p.close(); err != nil {
+			return err
+		}
+		p.stream, p.w = newWatchStream(sp, w), w
+	}
+	if err := p.stream.Write(obj); err != nil {
+		return err
+	}
+	return p.stream.Flush()
+}
+
+// Close implements io.Closer.
+func (p *WatchPrinter) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.close()
+}
+
+func (p *WatchPrinter) close() error {
+	if p.stream == nil {
+		return nil
+	}
+	stream := p.stream
+	p.stream, p.w = nil, nil
+	return stream.Close()
+}
+
+// watchStreamer is implemented by StreamingPrinters whose streams behave differently when they are
+// watched, like TablePrinter.
+type watchStreamer interface {
+	newWatchStream(w io.Writer) ObjectStream
+}
+
+// newWatchStream returns the stream that a WatchPrinter writes to.
+func newWatchStream(sp StreamingPrinter, w io.Writer) ObjectStream {
+	if ws, ok := sp.(watchStreamer); ok {
+		return ws.newWatchStream(w)
+	}
+	return sp.NewStream(w)
+}
+
+// NewWatchPrinter returns a WatchPrinter that wraps the given printer.
+func NewWatchPrinter(printer ObjectPrinter) *WatchPrinter {
+	return &WatchPrinter{Printer: printer}
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (c) 2024 Joel Cressy
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package printers_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jtcressy/go-cli-toolkit/cmdutil/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WatchPrinter", Label("unit"), func() {
+	type job struct {
+		Name   string
+		Status string
+	}
+
+	var buffer *bytes.Buffer
+
+	BeforeEach(func() {
+		buffer = new(bytes.Buffer)
+	})
+
+	It("should append CSV rows without repeating headers", func() {
+		printer := printers.NewWatchPrinter(printers.NewCSVPrinter(printers.PrintOptions{}))
+		Expect(printer.PrintObj([]job{{"build", "Running"}, {"test", "Pending"}}, buffer)).To(Succeed())
+		Expect(printer.PrintObj(job{"test", "Running"}, buffer)).To(Succeed())
+		Expect(printer.Close()).To(Succeed())
+		Expect(buffer.String()).To(Equal("NAME,STATUS\nbuild,Running\ntest,Pending\ntest,Running\n"))
+	})
+
+	It("should print JSON lines", func() {
+		printer := printers.NewWatchPrinter(printers.NewJSONPrinter(true))
+		Expect(printer.PrintObj(job{"build", "Running"}, buffer)).To(Succeed())
+		Expect(printer.PrintObj(job{"build", "Done"}, buffer)).To(Succeed())
+		Expect(printer.Close()).To(Succeed())
+		Expect(buffer.String()).To(Equal(
+			"{\"Name\":\"build\",\"Status\":\"Running\"}\n{\"Name\":\"build\",\"Status\":\"Done\"}\n",
+		))
+	})
+
+	It("should keep table columns aligned across calls", func() {
+		printer := printers.NewWatchPrinter(printers.NewTablePrinter(printers.PrintOptions{}))
+		Expect(printer.PrintObj(job{"build", "Running"}, buffer)).To(Succeed())
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		Expect(lines).To(HaveLen(4))
+		Expect(lines[1]).To(MatchRegexp(`^│\s*NAME\s*│\s*STATUS\s*│$`))
+
+		Expect(printer.PrintObj(job{"test", "Done"}, buffer)).To(Succeed())
+		Expect(printer.Close()).To(Succeed())
+		lines = strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		Expect(lines).To(HaveLen(6))
+		Expect(lines[4]).To(MatchRegexp(`^│\s*test\s*│\s*Done\s*│$`))
+		Expect(lines[5]).To(MatchRegexp(`^└─+┴─+┘$`))
+		for _, line := range lines {
+			Expect(len([]rune(line))).To(Equal(len([]rune(lines[0]))))
+		}
+	})
+
+	It("should widen table columns for later rows instead of truncating them", func() {
+		printer := printers.NewWatchPrinter(printers.NewTablePrinter(printers.PrintOptions{}))
+		Expect(printer.PrintObj(job{"a", "Running"}, buffer)).To(Succeed())
+		Expect(printer.PrintObj(job{"a-much-longer-name", "Succeeded"}, buffer)).To(Succeed())
+		Expect(printer.Close()).To(Succeed())
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		Expect(lines).To(HaveLen(6))
+		Expect(lines[4]).To(MatchRegexp(`^│\s*a-much-longer-name\s*│\s*Succeeded\s*│$`))
+		Expect(buffer.String()).NotTo(ContainSubstring("…"))
+		// the bottom border lines up with the widened columns
+		Expect(len([]rune(lines[5]))).To(Equal(len([]rune(lines[4]))))
+	})
+
+	It("should still truncate table columns with a maximum width", func() {
+		tablePrinter := printers.NewTablePrinter(printers.PrintOptions{})
+		tablePrinter.(*printers.TablePrinter).MaxColumnWidth = 10
+		printer := printers.NewWatchPrinter(tablePrinter)
+		Expect(printer.PrintObj(job{"a", "Running"}, buffer)).To(Succeed())
+		Expect(printer.PrintObj(job{"a-much-longer-name", "Done"}, buffer)).To(Succeed())
+		Expect(printer.Close()).To(Succeed())
+		Expect(buffer.String()).To(ContainSubstring("a-much-lo…"))
+	})
+
+	It("should not widen columns of plain streams", func() {
+		stream := printers.NewTablePrinter(printers.PrintOptions{}).(*printers.TablePrinter).NewStream(buffer)
+		Expect(stream.Write(job{"a", "Running"})).To(Succeed())
+		Expect(stream.Flush()).To(Succeed())
+		Expect(stream.Write(job{"a-much-longer-name", "Done"})).To(Succeed())
+		Expect(stream.Close()).To(Succeed())
+		Expect(buffer.String()).To(ContainSubstring("a-m…"))
+	})
+
+	It("should pass objects through to printers that do not support streaming", func() {
+		printer := printers.NewWatchPrinter(printers.ObjectPrinterFunc(func(obj any, w io.Writer) error {
+			_, err := fmt.Fprintf(w, "%v\n", obj)
+			return err
+		}))
+		Expect(printer.PrintObj(job{"build", "Running"}, buffer)).To(Succeed())
+		Expect(printer.PrintObj(job{"build", "Done"}, buffer)).To(Succeed())
+		Expect(printer.Close()).To(Succeed())
+		Expect(buffer.String()).To(Equal("{build Running}\n{build Done}\n"))
+	})
+})
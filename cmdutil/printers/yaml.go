@@ -32,16 +32,70 @@ const (
 	YAMLIndentLevel = 4
 )
 
-var _ ObjectPrinter = (*YamlPrinter)(nil)
+var _ StreamingPrinter = (*YamlPrinter)(nil)
 
-type YamlPrinter struct{}
+type YamlPrinter struct {
+	// SplitDocuments configures the printer to print every element of a slice, array, iterator
+	// function or channel as its own "---" separated YAML document, rather than as a single YAML
+	// sequence. Types that implement [yaml.Marshaler] are always printed as a single document.
+	SplitDocuments bool
+	// TimeFormat configures the time zone and layout of time.Time values.
+	TimeFormat TimeFormat
+}
 
 func (p *YamlPrinter) PrintObj(obj any, w io.Writer) error {
+	enc := p.newEncoder(w)
+	if err := p.encode(enc, obj); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// NewStream implements StreamingPrinter. Every object written to the stream is printed as its own
+// "---" separated YAML document, or as one document per element if SplitDocuments is set.
+func (p *YamlPrinter) NewStream(w io.Writer) ObjectStream {
+	return &yamlStream{printer: p, enc: p.newEncoder(w)}
+}
+
+func (p *YamlPrinter) newEncoder(w io.Writer) *yaml.Encoder {
 	enc := yaml.NewEncoder(w)
 	enc.SetIndent(YAMLIndentLevel)
-	return enc.Encode(obj)
+	return enc
+}
+
+func (p *YamlPrinter) encode(enc *yaml.Encoder, obj any) error {
+	encode := func(obj any) error { return p.TimeFormat.encodeYAML(enc, obj) }
+	if _, ok := obj.(yaml.Marshaler); ok || !p.SplitDocuments {
+		return encode(obj)
+	}
+	return rangeElements(obj, encode)
+}
+
+type yamlStream struct {
+	printer *YamlPrinter
+	enc     *yaml.Encoder
+}
+
+// Write implements ObjectStream.
+func (s *yamlStream) Write(obj any) error {
+	return s.printer.encode(s.enc, obj)
+}
+
+// Flush implements ObjectStream.
+func (s *yamlStream) Flush() error {
+	return nil
+}
+
+// Close implements ObjectStream.
+func (s *yamlStream) Close() error {
+	return s.enc.Close()
 }
 
 func NewYAMLPrinter() ObjectPrinter {
 	return &YamlPrinter{}
 }
+
+// NewYAMLStreamPrinter returns a YamlPrinter that prints collections as multiple YAML documents.
+func NewYAMLStreamPrinter() ObjectPrinter {
+	return &YamlPrinter{SplitDocuments: true}
+}
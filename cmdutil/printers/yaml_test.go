@@ -70,4 +70,49 @@ var _ = Describe("YamlPrinter", Label("unit"), func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Context("when splitting documents", func() {
+		BeforeEach(func() {
+			printer = printers.NewYAMLStreamPrinter()
+		})
+
+		It("should print each element of a slice as its own document", func() {
+			err := printer.PrintObj([]map[string]string{{"key": "a"}, {"key": "b"}}, buffer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer.String()).To(Equal("key: a\n---\nkey: b\n"))
+		})
+
+		It("should print a single object as one document", func() {
+			err := printer.PrintObj(map[string]string{"key": "value"}, buffer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer.String()).To(Equal("key: value\n"))
+		})
+
+		It("should print each element from an iterator function", func() {
+			src := func(yield func(int) bool) {
+				_ = yield(1) && yield(2)
+			}
+			Expect(printer.PrintObj(src, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("1\n---\n2\n"))
+		})
+
+		It("should print a document for every element written to a stream", func() {
+			stream := (&printers.YamlPrinter{SplitDocuments: true}).NewStream(buffer)
+			Expect(stream.Write([]int{1, 2})).To(Succeed())
+			Expect(stream.Write(3)).To(Succeed())
+			Expect(stream.Close()).To(Succeed())
+			Expect(buffer.String()).To(Equal("1\n---\n2\n---\n3\n"))
+		})
+	})
+
+	Context("when printing a stream", func() {
+		It("should print every object as its own document", func() {
+			ch := make(chan []int, 2)
+			ch <- []int{1, 2}
+			ch <- []int{3}
+			close(ch)
+			Expect(printers.PrintStream(printer, ch, buffer)).To(Succeed())
+			Expect(buffer.String()).To(Equal("- 1\n- 2\n---\n- 3\n"))
+		})
+	})
 })